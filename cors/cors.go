@@ -42,3 +42,56 @@ func MatchOrigin(origin, spec string) bool {
 func MatchOriginRegexp(origin string, spec *regexp.Regexp) bool {
 	return spec.Match([]byte(origin))
 }
+
+// MatchMethod returns true if the given method is one of the allowed methods.
+// The special method * matches any method.
+func MatchMethod(method string, allowed ...string) bool {
+	for _, m := range allowed {
+		if m == "*" || m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// ReflectHeaders returns the value of the Access-Control-Allow-Headers header
+// that authorizes the headers listed in the Access-Control-Request-Headers
+// header value requested. Requested headers that appear in denied are
+// omitted unless they also appear in allowed. Header names are compared
+// case-insensitively.
+func ReflectHeaders(requested string, allowed, denied []string) string {
+	var reflected []string
+	for _, h := range strings.Split(requested, ",") {
+		h = strings.TrimSpace(h)
+		if h == "" {
+			continue
+		}
+		if containsFold(denied, h) && !containsFold(allowed, h) {
+			continue
+		}
+		reflected = append(reflected, h)
+	}
+	return strings.Join(reflected, ", ")
+}
+
+// ReflectMethod returns the value of the Access-Control-Allow-Methods header
+// that authorizes the method requested in the Access-Control-Request-Method
+// header. It returns an empty string if the method appears in denied but not
+// in allowed. Methods are compared case-sensitively.
+func ReflectMethod(requested string, allowed, denied []string) string {
+	if MatchMethod(requested, denied...) && !MatchMethod(requested, allowed...) {
+		return ""
+	}
+	return requested
+}
+
+// containsFold returns true if vals contains s using case-insensitive
+// comparison.
+func containsFold(vals []string, s string) bool {
+	for _, v := range vals {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
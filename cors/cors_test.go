@@ -46,3 +46,71 @@ func TestMatchOrigin(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchMethod(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Method   string
+		Allowed  []string
+		Expected bool
+	}{
+		{"allowed", "POST", []string{"GET", "POST"}, true},
+		{"not-allowed", "DELETE", []string{"GET", "POST"}, false},
+		{"case-sensitive", "post", []string{"GET", "POST"}, false},
+		{"wildcard", "DELETE", []string{"*"}, true},
+		{"none", "GET", nil, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := MatchMethod(c.Method, c.Allowed...); actual != c.Expected {
+				t.Errorf("MatchMethod(%q, %v): got %t, expected %t", c.Method, c.Allowed, actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestReflectHeaders(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Requested string
+		Allowed   []string
+		Denied    []string
+		Expected  string
+	}{
+		{"empty", "", nil, nil, ""},
+		{"no-deny-list", "X-Time, Authorization", nil, nil, "X-Time, Authorization"},
+		{"denied", "X-Time,Authorization, cookie", nil, []string{"Authorization", "Cookie"}, "X-Time"},
+		{"denied-but-allowed", "X-Time, authorization", []string{"Authorization"}, []string{"Authorization", "Cookie"}, "X-Time, authorization"},
+		{"all-denied", "Cookie", nil, []string{"Cookie"}, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := ReflectHeaders(c.Requested, c.Allowed, c.Denied); actual != c.Expected {
+				t.Errorf("ReflectHeaders(%q, %v, %v): got %q, expected %q", c.Requested, c.Allowed, c.Denied, actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestReflectMethod(t *testing.T) {
+	cases := []struct {
+		Name      string
+		Requested string
+		Allowed   []string
+		Denied    []string
+		Expected  string
+	}{
+		{"no-deny-list", "DELETE", nil, nil, "DELETE"},
+		{"not-denied", "PUT", nil, []string{"DELETE"}, "PUT"},
+		{"denied", "DELETE", nil, []string{"DELETE"}, ""},
+		{"denied-but-allowed", "DELETE", []string{"DELETE"}, []string{"DELETE"}, "DELETE"},
+		{"case-sensitive", "delete", nil, []string{"DELETE"}, "delete"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := ReflectMethod(c.Requested, c.Allowed, c.Denied); actual != c.Expected {
+				t.Errorf("ReflectMethod(%q, %v, %v): got %q, expected %q", c.Requested, c.Allowed, c.Denied, actual, c.Expected)
+			}
+		})
+	}
+}
@@ -22,6 +22,12 @@ type (
 		Exposed []string
 		// Headers is the list of authorized headers, "*" authorizes all.
 		Headers []string
+		// DeniedHeaders is the list of headers that are not authorized when
+		// the requested headers are reflected, see ReflectHeaders.
+		DeniedHeaders []string
+		// DeniedMethods is the list of methods that are not authorized when
+		// the requested method is reflected, see ReflectMethods.
+		DeniedMethods []string
 		// MaxAge is the duration to cache a preflight request response.
 		MaxAge uint
 		// Credentials sets Access-Control-Allow-Credentials header in the
@@ -117,6 +123,45 @@ func (o *OriginExpr) EvalName() string {
 	return "CORS" + suffix
 }
 
+// ReflectHeaders returns true if the preflight responses must reflect the
+// requested headers. This is the case when the policy authorizes all headers
+// and allows credentials as browsers do not accept the "*" wildcard in the
+// Access-Control-Allow-Headers header of credentialed requests.
+func (o *OriginExpr) ReflectHeaders() bool {
+	return o.Credentials && contains(o.Headers, "*")
+}
+
+// ReflectMethods returns true if the preflight responses must reflect the
+// requested method. This is the case when the policy authorizes all methods
+// and allows credentials.
+func (o *OriginExpr) ReflectMethods() bool {
+	return o.Credentials && contains(o.Methods, "*")
+}
+
+// ExplicitHeaders returns the authorized headers listed explicitly, that is
+// all the authorized headers but the "*" wildcard.
+func (o *OriginExpr) ExplicitHeaders() []string {
+	var hs []string
+	for _, h := range o.Headers {
+		if h != "*" {
+			hs = append(hs, h)
+		}
+	}
+	return hs
+}
+
+// ExplicitMethods returns the authorized methods listed explicitly, that is
+// all the authorized methods but the "*" wildcard.
+func (o *OriginExpr) ExplicitMethods() []string {
+	var ms []string
+	for _, m := range o.Methods {
+		if m != "*" {
+			ms = append(ms, m)
+		}
+	}
+	return ms
+}
+
 // Validate ensures the origin expression is valid.
 func (o *OriginExpr) Validate() *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
@@ -129,5 +174,21 @@ func (o *OriginExpr) Validate() *eval.ValidationErrors {
 			verr.Add(o, "invalid origin, should be a valid regular expression")
 		}
 	}
+	if len(o.DeniedHeaders) > 0 && !o.ReflectHeaders() {
+		verr.Add(o, "denied headers can only be used when the requested headers are reflected, that is with Headers(\"*\") and Credentials()")
+	}
+	if len(o.DeniedMethods) > 0 && !o.ReflectMethods() {
+		verr.Add(o, "denied methods can only be used when the requested method is reflected, that is with Methods(\"*\") and Credentials()")
+	}
 	return verr
 }
+
+// contains returns true if vals contains s.
+func contains(vals []string, s string) bool {
+	for _, v := range vals {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
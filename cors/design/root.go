@@ -11,6 +11,13 @@ var Root = &RootExpr{
 	ServiceOrigins: map[string]*OriginExpr{},
 }
 
+const (
+	// EnvoyTarget identifies the Envoy proxy edge configuration.
+	EnvoyTarget = "envoy"
+	// NGINXTarget identifies the NGINX edge configuration.
+	NGINXTarget = "nginx"
+)
+
 type (
 	// RootExpr keeps track of the CORS origins defined in the design.
 	RootExpr struct {
@@ -20,6 +27,9 @@ type (
 		// ServiceOrigins lists all the CORS definitions indexed by origin string
 		// at the service level.
 		ServiceOrigins map[string]*OriginExpr
+		// EdgeTargets lists the edge proxies (EnvoyTarget or NGINXTarget)
+		// whose configuration is generated from the CORS policies.
+		EdgeTargets []string
 	}
 )
 
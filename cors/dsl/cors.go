@@ -136,6 +136,57 @@ func Headers(vals ...string) {
 	}
 }
 
+// DenyHeaders sets the headers that are not authorized when the policy
+// authorizes all headers with Headers("*") and allows credentials. In this case
+// the preflight responses reflect the headers listed in the request
+// Access-Control-Request-Headers header as browsers do not accept the "*"
+// wildcard for credentialed requests. The denied headers are omitted from the
+// reflected headers unless they are also listed explicitly in Headers.
+//
+// DenyHeaders must be used in an Origin expression.
+//
+// Example:
+//
+//     Origin("http://swagger.goa.design", func() {
+//         Headers("*")
+//         DenyHeaders("Authorization", "Cookie")
+//         Credentials()
+//     })
+//
+func DenyHeaders(vals ...string) {
+	switch o := eval.Current().(type) {
+	case *design.OriginExpr:
+		o.DeniedHeaders = append(o.DeniedHeaders, vals...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// DenyMethods sets the methods that are not authorized when the policy
+// authorizes all methods with Methods("*") and allows credentials. In this case
+// the preflight responses reflect the method given in the request
+// Access-Control-Request-Method header. The preflight requests for a denied
+// method are rejected unless the method is also listed explicitly in Methods.
+//
+// DenyMethods must be used in an Origin expression.
+//
+// Example:
+//
+//     Origin("http://swagger.goa.design", func() {
+//         Methods("*")
+//         DenyMethods("DELETE")
+//         Credentials()
+//     })
+//
+func DenyMethods(vals ...string) {
+	switch o := eval.Current().(type) {
+	case *design.OriginExpr:
+		o.DeniedMethods = append(o.DeniedMethods, vals...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
 // MaxAge sets the cache expiry for preflight request responses.
 //
 // MaxAge must be used in an Origin expression.
@@ -173,3 +224,44 @@ func Credentials() {
 		eval.IncompatibleDSL()
 	}
 }
+
+// EdgeConfig generates the configuration of edge proxies so that they enforce
+// the same CORS policies as the generated HTTP servers. The supported targets
+// are "envoy" which generates an Envoy route configuration fragment and "nginx"
+// which generates NGINX map and add_header include files.
+//
+// EdgeConfig must appear in an API expression.
+//
+// EdgeConfig accepts one or more target names.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Origin("http://swagger.goa.design")
+//        EdgeConfig("envoy", "nginx") // Generate Envoy and NGINX configurations
+//    })
+//
+func EdgeConfig(targets ...string) {
+	if _, ok := eval.Current().(*goadesign.APIExpr); !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	for _, t := range targets {
+		if t != design.EnvoyTarget && t != design.NGINXTarget {
+			eval.ReportError("invalid edge config target %q, must be one of %q or %q", t, design.EnvoyTarget, design.NGINXTarget)
+			return
+		}
+	}
+	for _, t := range targets {
+		found := false
+		for _, et := range design.Root.EdgeTargets {
+			if et == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			design.Root.EdgeTargets = append(design.Root.EdgeTargets, t)
+		}
+	}
+}
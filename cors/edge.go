@@ -0,0 +1,258 @@
+package cors
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"goa.design/goa/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/cors/design"
+)
+
+// pathParamRegexp matches the path parameters and wildcards of a route path.
+var pathParamRegexp = regexp.MustCompile(`{\*?[^}]*}`)
+
+// EdgeFiles returns the edge proxy configuration files that implement the CORS
+// policies of the services. Files are only generated for the targets listed
+// with the EdgeConfig DSL.
+func EdgeFiles(root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		data, ok := ServicesData[svc.Name()]
+		if !ok || len(data.Origins) == 0 {
+			continue
+		}
+		for _, t := range design.Root.EdgeTargets {
+			switch t {
+			case design.EnvoyTarget:
+				fw = append(fw, envoyFile(data))
+			case design.NGINXTarget:
+				fw = append(fw, nginxFiles(data)...)
+			}
+		}
+	}
+	return fw
+}
+
+// envoyFile returns the file containing the Envoy route configuration fragment
+// for the given service.
+func envoyFile(data *ServiceData) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(data.Name), "cors", "envoy.yaml")
+	sections := []*codegen.SectionTemplate{{
+		Name:    "cors-envoy",
+		Source:  envoyT,
+		Data:    data,
+		FuncMap: edgeFuncs(),
+	}}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// nginxFiles returns the files containing the NGINX maps and headers include
+// files for the given service.
+func nginxFiles(data *ServiceData) []*codegen.File {
+	dir := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(data.Name), "cors")
+	return []*codegen.File{
+		{
+			Path: filepath.Join(dir, "nginx_map.conf"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "cors-nginx-map",
+				Source:  nginxMapT,
+				Data:    data,
+				FuncMap: edgeFuncs(),
+			}},
+		},
+		{
+			Path: filepath.Join(dir, "nginx_headers.conf"),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "cors-nginx-headers",
+				Source:  nginxHeadersT,
+				Data:    data,
+				FuncMap: edgeFuncs(),
+			}},
+		},
+	}
+}
+
+// edgeFuncs returns the functions used by the edge configuration templates.
+func edgeFuncs() map[string]interface{} {
+	return map[string]interface{}{
+		"join":          strings.Join,
+		"snake":         codegen.SnakeCase,
+		"originPattern": originPattern,
+		"pathPattern":   pathPattern,
+	}
+}
+
+// originPattern returns a regular expression that matches the whole origin
+// string if and only if cors.MatchOrigin or cors.MatchOriginRegexp would match
+// it with the given policy.
+func originPattern(o *design.OriginExpr) string {
+	switch {
+	case o.Regexp:
+		return ".*(?:" + o.Origin + ").*"
+	case o.Origin == "*":
+		return ".*"
+	case strings.Contains(o.Origin, "*"):
+		parts := strings.SplitN(o.Origin, "*", 2)
+		return regexp.QuoteMeta(parts[0]) + ".*" + regexp.QuoteMeta(parts[1])
+	default:
+		return regexp.QuoteMeta(o.Origin)
+	}
+}
+
+// pathPattern returns a regular expression that matches the whole request
+// path if and only if the request path matches the given route path.
+func pathPattern(path string) string {
+	var (
+		pattern string
+		start   int
+	)
+	for _, loc := range pathParamRegexp.FindAllStringIndex(path, -1) {
+		pattern += regexp.QuoteMeta(path[start:loc[0]])
+		if strings.HasPrefix(path[loc[0]:], "{*") {
+			pattern += ".*"
+		} else {
+			pattern += "[^/]+"
+		}
+		start = loc[1]
+	}
+	return pattern + regexp.QuoteMeta(path[start:])
+}
+
+// Data: ServiceData
+var envoyT = `# {{ .Name }} Envoy CORS route configuration
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# There is one route per preflight path and origin policy followed by one route
+# for the requests made to the path without a matching origin. The routes
+# forward the requests to the "{{ .Name }}" cluster.
+routes:
+{{- range $path := .PreflightPaths }}
+	{{- range $policy := $.Origins }}
+- match:
+    regex: {{ pathPattern $path | printf "%q" }}
+    headers:
+    - name: origin
+      regex_match: {{ originPattern $policy | printf "%q" }}
+  route:
+    cluster: {{ printf "%q" $.Name }}
+    cors:
+      allow_origin_regex:
+      - {{ originPattern $policy | printf "%q" }}
+		{{- if $policy.Methods }}
+      allow_methods: {{ join $policy.Methods ", " | printf "%q" }}
+		{{- end }}
+		{{- if $policy.Headers }}
+      allow_headers: {{ join $policy.Headers ", " | printf "%q" }}
+		{{- end }}
+		{{- if $policy.Exposed }}
+      expose_headers: {{ join $policy.Exposed ", " | printf "%q" }}
+		{{- end }}
+		{{- if gt $policy.MaxAge 0 }}
+      max_age: "{{ $policy.MaxAge }}"
+		{{- end }}
+      allow_credentials: {{ $policy.Credentials }}
+	{{- end }}
+- match:
+    regex: {{ pathPattern $path | printf "%q" }}
+  route:
+    cluster: {{ printf "%q" $.Name }}
+{{- end }}
+`
+
+// Data: ServiceData
+var nginxMapT = `# {{ .Name }} NGINX CORS maps
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# Include this file in the http context and nginx_headers.conf in the server or
+# location blocks that serve the {{ .Name }} service.
+{{- $v := printf "$cors_%s" (snake .Name) }}
+map $http_origin {{ $v }}_policy {
+    default "";
+{{- range $i, $policy := .Origins }}
+    {{ printf "~^%s$" (originPattern $policy) | printf "%q" }} "{{ $i }}";
+{{- end }}
+}
+
+map {{ $v }}_policy {{ $v }}_allow_origin {
+    default "";
+{{- range $i, $policy := .Origins }}
+    "{{ $i }}" $http_origin;
+{{- end }}
+}
+
+map {{ $v }}_policy {{ $v }}_vary {
+    default "";
+{{- range $i, $policy := .Origins }}
+	{{- if not (eq $policy.Origin "*") }}
+    "{{ $i }}" "Origin";
+	{{- end }}
+{{- end }}
+}
+
+map {{ $v }}_policy {{ $v }}_expose_headers {
+    default "";
+{{- range $i, $policy := .Origins }}
+	{{- if $policy.Exposed }}
+    "{{ $i }}" {{ join $policy.Exposed ", " | printf "%q" }};
+	{{- end }}
+{{- end }}
+}
+
+map {{ $v }}_policy {{ $v }}_max_age {
+    default "";
+{{- range $i, $policy := .Origins }}
+	{{- if gt $policy.MaxAge 0 }}
+    "{{ $i }}" "{{ $policy.MaxAge }}";
+	{{- end }}
+{{- end }}
+}
+
+map {{ $v }}_policy {{ $v }}_allow_credentials {
+    default "";
+{{- range $i, $policy := .Origins }}
+    "{{ $i }}" "{{ $policy.Credentials }}";
+{{- end }}
+}
+
+map "{{ $v }}_policy:$http_access_control_request_method" {{ $v }}_allow_methods {
+    default "";
+{{- range $i, $policy := .Origins }}
+	{{- if $policy.Methods }}
+    "~^{{ $i }}:." {{ join $policy.Methods ", " | printf "%q" }};
+	{{- end }}
+{{- end }}
+}
+
+map "{{ $v }}_policy:$http_access_control_request_method" {{ $v }}_allow_headers {
+    default "";
+{{- range $i, $policy := .Origins }}
+	{{- if $policy.Headers }}
+    "~^{{ $i }}:." {{ join $policy.Headers ", " | printf "%q" }};
+	{{- end }}
+{{- end }}
+}
+`
+
+// Data: ServiceData
+var nginxHeadersT = `# {{ .Name }} NGINX CORS headers
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# Include this file in the server or location blocks that serve the following
+# {{ .Name }} service paths:
+{{- range .PreflightPaths }}
+#   {{ . }}
+{{- end }}
+{{- $v := printf "$cors_%s" (snake .Name) }}
+add_header Access-Control-Allow-Origin {{ $v }}_allow_origin always;
+add_header Vary {{ $v }}_vary always;
+add_header Access-Control-Expose-Headers {{ $v }}_expose_headers always;
+add_header Access-Control-Max-Age {{ $v }}_max_age always;
+add_header Access-Control-Allow-Credentials {{ $v }}_allow_credentials always;
+add_header Access-Control-Allow-Methods {{ $v }}_allow_methods always;
+add_header Access-Control-Allow-Headers {{ $v }}_allow_headers always;
+`
@@ -0,0 +1,91 @@
+package cors
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/cors/design"
+	"goa.design/plugins/cors/testdata"
+)
+
+func TestEdgeFiles(t *testing.T) {
+	cases := map[string]string{
+		"envoy.yaml":         testdata.EdgeEnvoyCode,
+		"nginx_map.conf":     testdata.EdgeNGINXMapCode,
+		"nginx_headers.conf": testdata.EdgeNGINXHeadersCode,
+	}
+	// Run another DSL first to make sure its origins do not leak into the
+	// edge configuration.
+	runDSL(t, testdata.MultiOriginDSL)
+	runDSL(t, testdata.EdgeConfigDSL)
+	fs, err := Generate("", []eval.Root{httpdesign.Root}, nil)
+	if err != nil {
+		t.Fatalf("generate error: %v", err)
+	}
+	if len(fs) != len(cases) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(cases))
+	}
+	for _, f := range fs {
+		name := filepath.Base(f.Path)
+		t.Run(name, func(t *testing.T) {
+			expCode, ok := cases[name]
+			if !ok {
+				t.Fatalf("unexpected file %s", f.Path)
+			}
+			if len(f.SectionTemplates) != 1 {
+				t.Fatalf("got %d sections, expected 1", len(f.SectionTemplates))
+			}
+			code := sectionContent(t, f.SectionTemplates[0])
+			if code != expCode {
+				t.Errorf("invalid content, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, expCode))
+			}
+		})
+	}
+}
+
+func TestOriginPattern(t *testing.T) {
+	cases := []struct {
+		Origin   string
+		Regexp   bool
+		Expected string
+	}{
+		{"*", false, ".*"},
+		{"http://swagger.goa.design", false, `http://swagger\.goa\.design`},
+		{"https://*.goa.design", false, `https://.*\.goa\.design`},
+		{"(api|swagger)[.]goa[.]design", true, ".*(?:(api|swagger)[.]goa[.]design).*"},
+	}
+	for _, c := range cases {
+		pattern := originPattern(&design.OriginExpr{Origin: c.Origin, Regexp: c.Regexp})
+		if pattern != c.Expected {
+			t.Errorf("originPattern(%q): got %q, expected %q", c.Origin, pattern, c.Expected)
+		}
+	}
+}
+
+func TestPathPattern(t *testing.T) {
+	cases := map[string]string{
+		"/":                 "/",
+		"/add/{a}/{b}":      "/add/[^/]+/[^/]+",
+		"/file.json":        `/file\.json`,
+		"/files/{*path}":    "/files/.*",
+		"/ids/{id}/details": "/ids/[^/]+/details",
+	}
+	for path, expected := range cases {
+		pattern := pathPattern(path)
+		if pattern != expected {
+			t.Errorf("pathPattern(%q): got %q, expected %q", path, pattern, expected)
+		}
+	}
+}
+
+func sectionContent(t *testing.T, section *codegen.SectionTemplate) string {
+	var buf bytes.Buffer
+	if err := section.Write(&buf); err != nil {
+		t.Fatalf("error writing section %s: %v", section.Name, err)
+	}
+	return buf.String()
+}
@@ -157,6 +157,7 @@ func handleCalcOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOriginRegexp(origin, spec0) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -166,7 +167,13 @@ func handleCalcOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				if !cors.MatchMethod(acrm, "GET", "POST") {
+					cors.NotifyPreflightRejected(o, r, ".*localhost.*")
+					origHndlr(w, r)
+					return
+				}
 			}
+			cors.NotifyMatched(o, r, ".*localhost.*")
 			origHndlr(w, r)
 			return
 		}
@@ -180,10 +187,17 @@ func handleCalcOrigin(h http.Handler) http.Handler {
 				// We are handling a preflight request
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
 				w.Header().Set("Access-Control-Allow-Headers", "X-Shared-Secret")
+				if !cors.MatchMethod(acrm, "GET", "POST") {
+					cors.NotifyPreflightRejected(o, r, "http://127.0.0.1")
+					origHndlr(w, r)
+					return
+				}
 			}
+			cors.NotifyMatched(o, r, "http://127.0.0.1")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
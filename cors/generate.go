@@ -40,7 +40,8 @@ func init() {
 }
 
 // Generate produces server code that handle preflight requests and updates
-// the HTTP responses with the appropriate CORS headers.
+// the HTTP responses with the appropriate CORS headers. It also produces the
+// edge proxy configurations listed in the design.
 func Generate(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
 	for _, root := range roots {
 		switch r := root.(type) {
@@ -52,6 +53,7 @@ func Generate(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codeg
 			for _, f := range files {
 				ServerCORS(f)
 			}
+			files = append(files, EdgeFiles(r)...)
 		}
 	}
 	return files, nil
@@ -201,6 +203,7 @@ func {{ .OriginHandler }}(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
     }
+		o := cors.ContextObserver(r.Context())
 	{{- range $i, $policy := .Origins }}
 		{{- if $policy.Regexp }}
 		if cors.MatchOriginRegexp(origin, spec{{$i}}) {
@@ -221,18 +224,52 @@ func {{ .OriginHandler }}(h http.Handler) http.Handler {
       if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
         // We are handling a preflight request
 				{{- if $policy.Methods }}
+					{{- if $policy.ReflectMethods }}
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				acm := cors.ReflectMethod(acrm, {{ template "stringSlice" $policy.ExplicitMethods }}, {{ template "stringSlice" $policy.DeniedMethods }})
+				if acm != "" {
+					w.Header().Set("Access-Control-Allow-Methods", acm)
+				}
+					{{- else }}
 				w.Header().Set("Access-Control-Allow-Methods", "{{ join $policy.Methods ", " }}")
+					{{- end }}
 				{{- end }}
 				{{- if $policy.Headers }}
+					{{- if $policy.ReflectHeaders }}
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				if acrh := cors.ReflectHeaders(r.Header.Get("Access-Control-Request-Headers"), {{ template "stringSlice" $policy.ExplicitHeaders }}, {{ template "stringSlice" $policy.DeniedHeaders }}); acrh != "" {
+					w.Header().Set("Access-Control-Allow-Headers", acrh)
+				}
+					{{- else }}
 				w.Header().Set("Access-Control-Allow-Headers", "{{ join $policy.Headers ", " }}")
+					{{- end }}
+				{{- end }}
+				{{- if $policy.ReflectMethods }}
+				if acm == "" {
+					cors.NotifyPreflightRejected(o, r, {{ printf "%q" $policy.Origin }})
+					origHndlr(w, r)
+					return
+				}
+				{{- else if $policy.Methods }}
+				if !cors.MatchMethod(acrm, {{ range $j, $m := $policy.Methods }}{{ if $j }}, {{ end }}{{ printf "%q" $m }}{{ end }}) {
+					cors.NotifyPreflightRejected(o, r, {{ printf "%q" $policy.Origin }})
+					origHndlr(w, r)
+					return
+				}
 				{{- end }}
 			}
+			cors.NotifyMatched(o, r, {{ printf "%q" $policy.Origin }})
 			origHndlr(w, r)
 			return
     }
 	{{- end }}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
   })
 }
+
+{{- define "stringSlice" }}
+	{{- if . }}[]string{ {{- range $i, $v := . }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end }}}{{ else }}nil{{ end }}
+{{- end }}
 `
@@ -9,6 +9,7 @@ import (
 	"goa.design/goa/eval"
 	httpcodegen "goa.design/goa/http/codegen"
 	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/cors/design"
 	"goa.design/plugins/cors/testdata"
 )
 
@@ -32,10 +33,11 @@ func NewCORSHandler() http.Handler {
 		{"multi-origin", testdata.MultiOriginDSL, testdata.MultiOriginHandleCode, testdata.MultiOriginMountCode, testdata.MultiOriginServerInitCode},
 		{"origin-file-server", testdata.OriginFileServerDSL, testdata.OriginFileServerHandleCode, testdata.OriginFileServerMountCode, testdata.OriginFileServerServerInitCode},
 		{"origin-multi-endpoint", testdata.OriginMultiEndpointDSL, testdata.OriginMultiEndpointHandleCode, testdata.OriginMultiEndpointMountCode, testdata.OriginMultiEndpointServerInitCode},
+		{"reflect-origin", testdata.ReflectOriginDSL, testdata.ReflectOriginHandleCode, testdata.ReflectOriginMountCode, testdata.ReflectOriginServerInitCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
-			httpcodegen.RunHTTPDSL(t, c.DSL)
+			runDSL(t, c.DSL)
 			fs := httpcodegen.ServerFiles("", httpdesign.Root)
 			if len(fs) != 2 {
 				t.Fatalf("got %d files, expected two", len(fs))
@@ -69,6 +71,17 @@ func NewCORSHandler() http.Handler {
 	}
 }
 
+// runDSL resets the CORS design root and runs the given DSL. RunHTTPDSL only
+// resets the goa roots, the origins and edge targets defined by the DSLs run
+// previously would otherwise leak into the design.
+func runDSL(t *testing.T, dsl func()) {
+	design.Root = &design.RootExpr{
+		APIOrigins:     map[string]*design.OriginExpr{},
+		ServiceOrigins: map[string]*design.OriginExpr{},
+	}
+	httpcodegen.RunHTTPDSL(t, dsl)
+}
+
 func testCode(t *testing.T, file *codegen.File, section, expCode string) {
 	sections := file.Section(section)
 	if len(sections) < 1 {
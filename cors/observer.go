@@ -0,0 +1,173 @@
+package cors
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/go-kit/kit/metrics"
+)
+
+type (
+	// Observer is notified of the outcome of the CORS requests handled by the
+	// generated origin handlers. Use Observe to register an observer with the
+	// HTTP handler that serves the service endpoints.
+	Observer interface {
+		// Matched is called when the request origin matches one of the
+		// service origin policies.
+		Matched(*Event)
+		// Unmatched is called when the origin of a request that is not a
+		// preflight request does not match any of the service origin
+		// policies.
+		Unmatched(*Event)
+		// PreflightRejected is called when the origin of a preflight request
+		// does not match any of the service origin policies or when the
+		// requested method is not allowed by the matched policy.
+		PreflightRejected(*Event)
+	}
+
+	// Event describes a CORS request.
+	Event struct {
+		// Origin is the value of the request Origin header.
+		Origin string
+		// Path is the request URL path.
+		Path string
+		// Method is the request HTTP method. For preflight requests this is
+		// the value of the Access-Control-Request-Method header.
+		Method string
+		// Policy is the origin specification of the matched policy, empty if
+		// the origin does not match any policy.
+		Policy string
+	}
+
+	// counterObserver is an observer that records events with a go-kit
+	// counter.
+	counterObserver struct {
+		counter metrics.Counter
+	}
+
+	// loggerObserver is an observer that logs events with a standard
+	// logger.
+	loggerObserver struct {
+		logger *log.Logger
+	}
+
+	// observerKey is the key used to store the observer in the request
+	// context.
+	observerKey struct{}
+)
+
+// Observe returns a HTTP handler that notifies o of the CORS requests handled
+// by the origin handlers of the service endpoints served by h, typically the
+// server mux. The observer is stored in the request context so that each
+// server may use its own observer.
+func Observe(h http.Handler, o Observer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), observerKey{}, o)))
+	})
+}
+
+// ContextObserver returns the observer stored in ctx by Observe, nil if there
+// isn't one.
+func ContextObserver(ctx context.Context) Observer {
+	o, _ := ctx.Value(observerKey{}).(Observer)
+	return o
+}
+
+// NewCounterObserver returns an observer that increments the given counter
+// once per event. The counter is labeled with "event" (one of "matched",
+// "unmatched" or "preflight_rejected"), "method" and "policy". The origin is
+// not used as label value to keep the cardinality of the metric bounded.
+func NewCounterObserver(counter metrics.Counter) Observer {
+	return &counterObserver{counter: counter}
+}
+
+// NewLoggerObserver returns an observer that logs the unmatched and rejected
+// preflight events with the given logger. Matched events are not logged.
+func NewLoggerObserver(logger *log.Logger) Observer {
+	return &loggerObserver{logger: logger}
+}
+
+// NotifyMatched notifies o that the request origin matched the given policy.
+// It does nothing if o is nil.
+func NotifyMatched(o Observer, r *http.Request, policy string) {
+	if o == nil {
+		return
+	}
+	o.Matched(newEvent(r, policy))
+}
+
+// NotifyUnmatched notifies o that the request origin did not match any
+// policy. Preflight requests are reported as rejected preflights. It does
+// nothing if o is nil.
+func NotifyUnmatched(o Observer, r *http.Request) {
+	if o == nil {
+		return
+	}
+	if r.Header.Get("Access-Control-Request-Method") != "" {
+		o.PreflightRejected(newEvent(r, ""))
+		return
+	}
+	o.Unmatched(newEvent(r, ""))
+}
+
+// NotifyPreflightRejected notifies o that the preflight request origin
+// matched the given policy but that the requested method is not allowed. It
+// does nothing if o is nil.
+func NotifyPreflightRejected(o Observer, r *http.Request, policy string) {
+	if o == nil {
+		return
+	}
+	o.PreflightRejected(newEvent(r, policy))
+}
+
+// Matched increments the counter with the "matched" event label.
+func (o *counterObserver) Matched(e *Event) {
+	o.add("matched", e)
+}
+
+// Unmatched increments the counter with the "unmatched" event label.
+func (o *counterObserver) Unmatched(e *Event) {
+	o.add("unmatched", e)
+}
+
+// PreflightRejected increments the counter with the "preflight_rejected"
+// event label.
+func (o *counterObserver) PreflightRejected(e *Event) {
+	o.add("preflight_rejected", e)
+}
+
+func (o *counterObserver) add(event string, e *Event) {
+	o.counter.With("event", event, "method", e.Method, "policy", e.Policy).Add(1)
+}
+
+// Matched does nothing.
+func (o *loggerObserver) Matched(e *Event) {}
+
+// Unmatched logs the event.
+func (o *loggerObserver) Unmatched(e *Event) {
+	o.logger.Printf("[cors] unmatched origin %q: %s %s", e.Origin, e.Method, e.Path)
+}
+
+// PreflightRejected logs the event.
+func (o *loggerObserver) PreflightRejected(e *Event) {
+	if e.Policy == "" {
+		o.logger.Printf("[cors] rejected preflight, unmatched origin %q: %s %s", e.Origin, e.Method, e.Path)
+		return
+	}
+	o.logger.Printf("[cors] rejected preflight, method not allowed by policy %q for origin %q: %s %s", e.Policy, e.Origin, e.Method, e.Path)
+}
+
+// newEvent builds the event describing the given request.
+func newEvent(r *http.Request, policy string) *Event {
+	method := r.Method
+	if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
+		method = acrm
+	}
+	return &Event{
+		Origin: r.Header.Get("Origin"),
+		Path:   r.URL.Path,
+		Method: method,
+		Policy: policy,
+	}
+}
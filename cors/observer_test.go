@@ -0,0 +1,142 @@
+package cors
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+)
+
+type recordingObserver struct {
+	kinds  []string
+	events []*Event
+}
+
+func (o *recordingObserver) Matched(e *Event)           { o.record("matched", e) }
+func (o *recordingObserver) Unmatched(e *Event)         { o.record("unmatched", e) }
+func (o *recordingObserver) PreflightRejected(e *Event) { o.record("preflight_rejected", e) }
+
+func (o *recordingObserver) record(kind string, e *Event) {
+	o.kinds = append(o.kinds, kind)
+	o.events = append(o.events, e)
+}
+
+type recordingCounter struct {
+	labels [][]string
+	deltas []float64
+}
+
+func (c *recordingCounter) With(lvs ...string) metrics.Counter {
+	c.labels = append(c.labels, lvs)
+	return c
+}
+
+func (c *recordingCounter) Add(delta float64) {
+	c.deltas = append(c.deltas, delta)
+}
+
+func TestNotify(t *testing.T) {
+	var (
+		matched  = func(o Observer, r *http.Request) { NotifyMatched(o, r, "*.goa.design") }
+		rejected = func(o Observer, r *http.Request) { NotifyPreflightRejected(o, r, "*.goa.design") }
+	)
+	cases := []struct {
+		Name     string
+		Method   string
+		ACRM     string
+		Notify   func(Observer, *http.Request)
+		Kind     string
+		Expected *Event
+	}{
+		{"matched", "GET", "", matched, "matched", &Event{Origin: "http://goa.design", Path: "/path", Method: "GET", Policy: "*.goa.design"}},
+		{"matched-preflight", "OPTIONS", "PUT", matched, "matched", &Event{Origin: "http://goa.design", Path: "/path", Method: "PUT", Policy: "*.goa.design"}},
+		{"unmatched", "GET", "", NotifyUnmatched, "unmatched", &Event{Origin: "http://goa.design", Path: "/path", Method: "GET"}},
+		{"unmatched-preflight", "OPTIONS", "PUT", NotifyUnmatched, "preflight_rejected", &Event{Origin: "http://goa.design", Path: "/path", Method: "PUT"}},
+		{"rejected-preflight", "OPTIONS", "PUT", rejected, "preflight_rejected", &Event{Origin: "http://goa.design", Path: "/path", Method: "PUT", Policy: "*.goa.design"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest(c.Method, "http://localhost/path", nil)
+			r.Header.Set("Origin", "http://goa.design")
+			if c.ACRM != "" {
+				r.Header.Set("Access-Control-Request-Method", c.ACRM)
+			}
+			o := &recordingObserver{}
+			c.Notify(o, r)
+			if len(o.kinds) != 1 {
+				t.Fatalf("got %d events, expected 1", len(o.kinds))
+			}
+			if o.kinds[0] != c.Kind {
+				t.Errorf("got event %q, expected %q", o.kinds[0], c.Kind)
+			}
+			if !reflect.DeepEqual(o.events[0], c.Expected) {
+				t.Errorf("got event %+v, expected %+v", o.events[0], c.Expected)
+			}
+		})
+	}
+}
+
+func TestNotifyNilObserver(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://localhost/path", nil)
+	NotifyMatched(nil, r, "*")
+	NotifyUnmatched(nil, r)
+	NotifyPreflightRejected(nil, r, "*")
+}
+
+func TestObserve(t *testing.T) {
+	o := &recordingObserver{}
+	var actual Observer
+	h := Observe(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual = ContextObserver(r.Context())
+	}), o)
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "http://localhost/path", nil))
+	if actual != o {
+		t.Errorf("got observer %v, expected %v", actual, o)
+	}
+	if ContextObserver(httptest.NewRequest("GET", "http://localhost/path", nil).Context()) != nil {
+		t.Errorf("expected no observer in a request context without observer")
+	}
+}
+
+func TestCounterObserver(t *testing.T) {
+	c := &recordingCounter{}
+	o := NewCounterObserver(c)
+	o.Matched(&Event{Origin: "http://goa.design", Method: "GET", Policy: "*"})
+	o.Unmatched(&Event{Origin: "http://goa.design", Method: "POST"})
+	o.PreflightRejected(&Event{Origin: "http://goa.design", Method: "PUT", Policy: "*"})
+	expected := [][]string{
+		{"event", "matched", "method", "GET", "policy", "*"},
+		{"event", "unmatched", "method", "POST", "policy", ""},
+		{"event", "preflight_rejected", "method", "PUT", "policy", "*"},
+	}
+	if !reflect.DeepEqual(c.labels, expected) {
+		t.Errorf("got labels %v, expected %v", c.labels, expected)
+	}
+	if !reflect.DeepEqual(c.deltas, []float64{1, 1, 1}) {
+		t.Errorf("got deltas %v, expected [1 1 1]", c.deltas)
+	}
+}
+
+func TestLoggerObserver(t *testing.T) {
+	var buf bytes.Buffer
+	o := NewLoggerObserver(log.New(&buf, "", 0))
+	o.Matched(&Event{Origin: "http://goa.design", Path: "/", Method: "GET", Policy: "*"})
+	if buf.Len() != 0 {
+		t.Errorf("matched event: got log %q, expected none", buf.String())
+	}
+	o.Unmatched(&Event{Origin: "http://goa.design", Path: "/", Method: "GET"})
+	o.PreflightRejected(&Event{Origin: "http://goa.design", Path: "/", Method: "PUT", Policy: "*"})
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		`[cors] unmatched origin "http://goa.design": GET /`,
+		`[cors] rejected preflight, method not allowed by policy "*" for origin "http://goa.design": PUT /`,
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("got logs %q, expected %q", lines, expected)
+	}
+}
@@ -11,6 +11,7 @@ func handleSimpleOriginOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOrigin(origin, "SimpleOrigin") {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -18,9 +19,11 @@ func handleSimpleOriginOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 			}
+			cors.NotifyMatched(o, r, "SimpleOrigin")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
@@ -39,6 +42,7 @@ func handleRegexpOriginOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOriginRegexp(origin, spec0) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -46,9 +50,11 @@ func handleRegexpOriginOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 			}
+			cors.NotifyMatched(o, r, ".*RegexpOrigin.*")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
@@ -67,6 +73,7 @@ func handleMultiOriginOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOriginRegexp(origin, spec0) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -76,7 +83,13 @@ func handleMultiOriginOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				if !cors.MatchMethod(acrm, "GET", "POST") {
+					cors.NotifyPreflightRejected(o, r, ".*MultiOrigin2.*")
+					origHndlr(w, r)
+					return
+				}
 			}
+			cors.NotifyMatched(o, r, ".*MultiOrigin2.*")
 			origHndlr(w, r)
 			return
 		}
@@ -90,10 +103,17 @@ func handleMultiOriginOrigin(h http.Handler) http.Handler {
 				// We are handling a preflight request
 				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
 				w.Header().Set("Access-Control-Allow-Headers", "X-Shared-Secret")
+				if !cors.MatchMethod(acrm, "GET", "POST") {
+					cors.NotifyPreflightRejected(o, r, "MultiOrigin1")
+					origHndlr(w, r)
+					return
+				}
 			}
+			cors.NotifyMatched(o, r, "MultiOrigin1")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
@@ -111,6 +131,7 @@ func handleOriginFileServerOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOrigin(origin, "OriginFileServer") {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -118,9 +139,11 @@ func handleOriginFileServerOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 			}
+			cors.NotifyMatched(o, r, "OriginFileServer")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
@@ -138,6 +161,7 @@ func handleOriginMultiEndpointOrigin(h http.Handler) http.Handler {
 			origHndlr(w, r)
 			return
 		}
+		o := cors.ContextObserver(r.Context())
 		if cors.MatchOrigin(origin, "OriginMultiEndpoint") {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
 			w.Header().Set("Vary", "Origin")
@@ -145,9 +169,55 @@ func handleOriginMultiEndpointOrigin(h http.Handler) http.Handler {
 			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
 				// We are handling a preflight request
 			}
+			cors.NotifyMatched(o, r, "OriginMultiEndpoint")
 			origHndlr(w, r)
 			return
 		}
+		cors.NotifyUnmatched(o, r)
+		origHndlr(w, r)
+		return
+	})
+}
+`
+
+var ReflectOriginHandleCode = `// handleReflectOriginOrigin applies the CORS response headers corresponding to
+// the origin for the service ReflectOrigin.
+func handleReflectOriginOrigin(h http.Handler) http.Handler {
+	origHndlr := h.(http.HandlerFunc)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			// Not a CORS request
+			origHndlr(w, r)
+			return
+		}
+		o := cors.ContextObserver(r.Context())
+		if cors.MatchOrigin(origin, "ReflectOrigin") {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			if acrm := r.Header.Get("Access-Control-Request-Method"); acrm != "" {
+				// We are handling a preflight request
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				acm := cors.ReflectMethod(acrm, []string{"PATCH"}, []string{"DELETE", "PATCH"})
+				if acm != "" {
+					w.Header().Set("Access-Control-Allow-Methods", acm)
+				}
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				if acrh := cors.ReflectHeaders(r.Header.Get("Access-Control-Request-Headers"), []string{"X-Api-Version"}, []string{"Authorization", "Cookie"}); acrh != "" {
+					w.Header().Set("Access-Control-Allow-Headers", acrh)
+				}
+				if acm == "" {
+					cors.NotifyPreflightRejected(o, r, "ReflectOrigin")
+					origHndlr(w, r)
+					return
+				}
+			}
+			cors.NotifyMatched(o, r, "ReflectOrigin")
+			origHndlr(w, r)
+			return
+		}
+		cors.NotifyUnmatched(o, r)
 		origHndlr(w, r)
 		return
 	})
@@ -225,6 +295,20 @@ func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
 }
 `
 
+var ReflectOriginMountCode = `// MountCORSHandler configures the mux to serve the CORS endpoints for the
+// service ReflectOrigin.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = handleReflectOriginOrigin(h)
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("OPTIONS", "/", f)
+}
+`
+
 var SimpleOriginServerInitCode = `// New instantiates HTTP handlers for all the SimpleOrigin service endpoints.
 func New(
 	e *simpleorigin.Endpoints,
@@ -325,3 +409,22 @@ func New(
 	}
 }
 `
+
+var ReflectOriginServerInitCode = `// New instantiates HTTP handlers for all the ReflectOrigin service endpoints.
+func New(
+	e *reflectorigin.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"ReflectOriginMethod", "GET", "/"},
+			{"CORS", "OPTIONS", "/"},
+		},
+		ReflectOriginMethod: NewReflectOriginMethodHandler(e.ReflectOriginMethod, mux, dec, enc, eh),
+		CORS:                NewCORSHandler(),
+	}
+}
+`
@@ -76,3 +76,39 @@ var OriginMultiEndpointDSL = func() {
 		})
 	})
 }
+
+var ReflectOriginDSL = func() {
+	Service("ReflectOrigin", func() {
+		Origin("ReflectOrigin", func() {
+			Headers("*", "X-Api-Version")
+			DenyHeaders("Authorization", "Cookie")
+			Methods("*", "PATCH")
+			DenyMethods("DELETE", "PATCH")
+			Credentials()
+		})
+		Method("ReflectOriginMethod", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var EdgeConfigDSL = func() {
+	API("EdgeConfig", func() {
+		EdgeConfig("envoy", "nginx")
+	})
+	Service("Edge", func() {
+		Origin("*.goa.design", func() {
+			Headers("X-Shared-Secret")
+			Methods("GET")
+			Credentials()
+		})
+		Method("EdgeMethod", func() {
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Files("/files/{*path}", "./files")
+	})
+}
@@ -0,0 +1,107 @@
+package testdata
+
+var EdgeEnvoyCode = `# Edge Envoy CORS route configuration
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# There is one route per preflight path and origin policy followed by one route
+# for the requests made to the path without a matching origin. The routes
+# forward the requests to the "Edge" cluster.
+routes:
+- match:
+    regex: "/[^/]+"
+    headers:
+    - name: origin
+      regex_match: ".*\\.goa\\.design"
+  route:
+    cluster: "Edge"
+    cors:
+      allow_origin_regex:
+      - ".*\\.goa\\.design"
+      allow_methods: "GET"
+      allow_headers: "X-Shared-Secret"
+      allow_credentials: true
+- match:
+    regex: "/[^/]+"
+  route:
+    cluster: "Edge"
+- match:
+    regex: "/files/.*"
+    headers:
+    - name: origin
+      regex_match: ".*\\.goa\\.design"
+  route:
+    cluster: "Edge"
+    cors:
+      allow_origin_regex:
+      - ".*\\.goa\\.design"
+      allow_methods: "GET"
+      allow_headers: "X-Shared-Secret"
+      allow_credentials: true
+- match:
+    regex: "/files/.*"
+  route:
+    cluster: "Edge"
+`
+
+var EdgeNGINXMapCode = `# Edge NGINX CORS maps
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# Include this file in the http context and nginx_headers.conf in the server or
+# location blocks that serve the Edge service.
+map $http_origin $cors_edge_policy {
+    default "";
+    "~^.*\\.goa\\.design$" "0";
+}
+
+map $cors_edge_policy $cors_edge_allow_origin {
+    default "";
+    "0" $http_origin;
+}
+
+map $cors_edge_policy $cors_edge_vary {
+    default "";
+    "0" "Origin";
+}
+
+map $cors_edge_policy $cors_edge_expose_headers {
+    default "";
+}
+
+map $cors_edge_policy $cors_edge_max_age {
+    default "";
+}
+
+map $cors_edge_policy $cors_edge_allow_credentials {
+    default "";
+    "0" "true";
+}
+
+map "$cors_edge_policy:$http_access_control_request_method" $cors_edge_allow_methods {
+    default "";
+    "~^0:." "GET";
+}
+
+map "$cors_edge_policy:$http_access_control_request_method" $cors_edge_allow_headers {
+    default "";
+    "~^0:." "X-Shared-Secret";
+}
+`
+
+var EdgeNGINXHeadersCode = `# Edge NGINX CORS headers
+#
+# Code generated by goa, DO NOT EDIT.
+#
+# Include this file in the server or location blocks that serve the following
+# Edge service paths:
+#   /{id}
+#   /files/{*path}
+add_header Access-Control-Allow-Origin $cors_edge_allow_origin always;
+add_header Vary $cors_edge_vary always;
+add_header Access-Control-Expose-Headers $cors_edge_expose_headers always;
+add_header Access-Control-Max-Age $cors_edge_max_age always;
+add_header Access-Control-Allow-Credentials $cors_edge_allow_credentials always;
+add_header Access-Control-Allow-Methods $cors_edge_allow_methods always;
+add_header Access-Control-Allow-Headers $cors_edge_allow_headers always;
+`
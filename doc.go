@@ -3,16 +3,26 @@ Packages in the Plugin directory contain plugins for [goa
 v2](https://godoc.org/goa.design/goa). Plugins can extend the goa DSL, generate
 new artifacts and modify the output of existing generators.
 
-There are currently two plugins in the directory:
+There are currently four plugins in the directory:
+
+	* The [cors](https://godoc.org/goa.design/plugins/cors) plugin adds new DSL
+	  to define CORS policies. The plugin generates HTTP server code that sets
+	  the CORS response headers and handles the preflight requests.
 
 	* The [goakit](https://godoc.org/goa.design/plugins/goakit) plugin generates
 	 code that integrates with the [go-kit](https://github.com/go-kit/kit) library.
 
 	* The [security](https://godoc.org/goa.design/plugins/security) plugin
 	  adds new DSL to define security schemes and identify endpoints that require
-	  auth. The plugin generates HTTP server code that implement the security schemes.
+	  auth. The plugin generates HTTP server code that extracts the credentials
+	  and calls user provided authorizer functions before the endpoints run.
 	  The supported schemes are basic auth, API key, OAuth2 and JWT.
 
+	* The [zaplogger](https://godoc.org/goa.design/plugins/zaplogger) plugin
+	  modifies the generated example main so that the services log with
+	  [zap](https://github.com/uber-go/zap) and provides per-request loggers
+	  that carry the request ID, service and method.
+
 Writing a Plugin
 
 Writing a plugin consists of two steps:
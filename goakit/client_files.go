@@ -0,0 +1,105 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ClientFiles produces the files containing the go-kit HTTP client
+// constructors that build the service endpoints.
+func ClientFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, len(root.HTTPServices))
+	for i, svc := range root.HTTPServices {
+		fw[i] = clientFile(genpkg, svc)
+	}
+	return fw
+}
+
+// clientFile returns the file defining the go-kit HTTP client constructors for
+// the given service.
+func clientFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "client.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit HTTP client", svc.Name())
+	fm := codegen.TemplateFuncs()
+	fm["hasClientMiddleware"] = hasClientMiddleware
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "net/http"},
+			{Path: "net/url"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/client"},
+		}),
+		{
+			Name:    "goakit-client-new",
+			Source:  clientNewT,
+			Data:    data,
+			FuncMap: fm,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-client",
+			Source: clientT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const clientNewT = `{{ printf "New returns the %s service endpoints implemented with go-kit HTTP clients that make requests to the given host using the given scheme. The endpoints apply the client timeouts, retries and circuit breakers defined in the design." .Service.Name | comment }}
+func New(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *{{ .Service.PkgName }}.Endpoints {
+	return &{{ .Service.PkgName }}.Endpoints{
+	{{- range .Endpoints }}
+		{{- if hasClientMiddleware .ServiceName .Method.Name }}
+		{{ .Method.VarName }}: {{ .Method.VarName }}Middleware()(New{{ .Method.VarName }}Client(scheme, host, enc, dec, opts...).Endpoint()),
+		{{- else }}
+		{{ .Method.VarName }}: New{{ .Method.VarName }}Client(scheme, host, enc, dec, opts...).Endpoint(),
+		{{- end }}
+	{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const clientT = `{{ printf "New%sClient returns a go-kit HTTP client that makes requests to the %s service %s endpoint. The request path is built from the payload so that it includes the path parameters." .Method.VarName .ServiceName .Method.Name | comment }}
+func New{{ .Method.VarName }}Client(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *kithttp.Client {
+	c := client.NewClient(scheme, host, nil, enc, dec, false)
+{{- if .RequestEncoder }}
+	encode := {{ .RequestEncoder }}(enc)
+{{- end }}
+	return kithttp.NewClient(
+		{{ printf "%q" (index .Routes 0).Verb }},
+		&url.URL{Scheme: scheme, Host: host},
+		func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := c.Build{{ .Method.VarName }}Request(ctx, v)
+			if err != nil {
+				return err
+			}
+			r.Method = req.Method
+			r.URL = req.URL
+			r.Host = req.Host
+{{- if .RequestEncoder }}
+			return encode(ctx, r, v)
+{{- else }}
+			return nil
+{{- end }}
+		},
+{{- if or .Result .Errors }}
+		{{ .ResponseDecoder }}(dec),
+{{- else }}
+		func(context.Context, *http.Response) (interface{}, error) { return nil, nil },
+{{- end }}
+		opts...,
+	)
+}
+`
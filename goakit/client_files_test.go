@@ -0,0 +1,50 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestClientFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code map[string][]string
+	}{
+		"simple-service": {
+			DSL: testdata.SimpleServiceDSL,
+			Code: map[string][]string{
+				"goakit-client-new": []string{testdata.SimpleServiceGoakitClientNewCode},
+				"goakit-client":     []string{testdata.SimpleMethodGoakitClientCode},
+			},
+		},
+		"with-payload": {
+			DSL: testdata.WithPayloadDSL,
+			Code: map[string][]string{
+				"goakit-client-new": []string{testdata.WithPayloadServiceGoakitClientNewCode},
+				"goakit-client":     []string{testdata.WithPayloadMethodGoakitClientCode},
+			},
+		},
+		"multi-endpoints": {
+			DSL: testdata.MultiEndpointDSL,
+			Code: map[string][]string{
+				"goakit-client-new": []string{testdata.MultiEndpointServiceGoakitClientNewCode},
+				"goakit-client":     []string{testdata.Endpoint1GoakitClientCode, testdata.Endpoint2GoakitClientCode},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			for sec, secCode := range c.Code {
+				testCode(t, fs[0], sec, secCode)
+			}
+		})
+	}
+}
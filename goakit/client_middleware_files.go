@@ -0,0 +1,176 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/design"
+)
+
+// ClientMiddlewareFiles produces the files containing the go-kit endpoint
+// middlewares that apply the client timeouts, retries and circuit breakers
+// defined in the design. Services whose methods define none of these settings
+// are skipped.
+func ClientMiddlewareFiles(root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		if f := clientMiddlewareFile(svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// clientMiddlewareFile returns the file defining the client middlewares of
+// the given service or nil if the service methods define no client setting.
+func clientMiddlewareFile(svc *httpdesign.ServiceExpr) *codegen.File {
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	var sections []*codegen.SectionTemplate
+	fm := codegen.TemplateFuncs()
+	fm["duration"] = durationCode
+	fm["millis"] = func(d time.Duration) int64 { return int64(d / time.Millisecond) }
+	fm["add"] = func(a, b int) int { return a + b }
+	for _, e := range data.Endpoints {
+		c := design.Root.Client(e.ServiceName, e.Method.Name)
+		if c == nil || !c.HasMiddleware() {
+			continue
+		}
+		data := map[string]interface{}{"Endpoint": e, "Client": c}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "goakit-client-middleware",
+			Source:  clientMiddlewareT,
+			Data:    data,
+			FuncMap: fm,
+		})
+		if c.Breaker != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "goakit-client-breaker",
+				Source:  clientBreakerT,
+				Data:    data,
+				FuncMap: fm,
+			})
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "middleware.go")
+	title := fmt.Sprintf("%s go-kit HTTP client middlewares", svc.Name())
+	header := codegen.Header(title, "client", []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "time"},
+		{Path: "github.com/afex/hystrix-go/hystrix"},
+		{Path: "github.com/go-kit/kit/circuitbreaker"},
+		{Path: "github.com/go-kit/kit/endpoint"},
+		{Path: "github.com/go-kit/kit/sd"},
+		{Path: "github.com/go-kit/kit/sd/lb"},
+		{Path: "github.com/sony/gobreaker"},
+	})
+
+	return &codegen.File{Path: path, SectionTemplates: append([]*codegen.SectionTemplate{header}, sections...)}
+}
+
+// hasClientMiddleware returns true if the design defines client settings that
+// require wrapping the client endpoint of the given method.
+func hasClientMiddleware(svc, method string) bool {
+	c := design.Root.Client(svc, method)
+	return c != nil && c.HasMiddleware()
+}
+
+// balanceArgs returns the code of the number of attempts, the timeout and the
+// instance middleware given to the balance function of the load balanced
+// endpoints constructor for the given method. The retries, timeout and circuit
+// breaker defined in the design take precedence over the load balancer
+// options.
+func balanceArgs(svc, method, varName string) string {
+	attempts, timeout, mw := "1", "o.Timeout", "nil"
+	if c := design.Root.Client(svc, method); c != nil {
+		if c.Idempotent {
+			attempts = "o.MaxAttempts"
+		}
+		if c.Retries > 0 {
+			attempts = strconv.Itoa(c.Retries + 1)
+		}
+		if c.Timeout > 0 {
+			timeout = durationCode(c.Timeout)
+		}
+		if c.Breaker != nil {
+			mw = varName + "Breaker()"
+		}
+	}
+	return strings.Join([]string{attempts, timeout, mw}, ", ")
+}
+
+// durationCode returns the Go expression of the given duration.
+func durationCode(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%d * time.Hour", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%d * time.Second", d/time.Second)
+	case d%time.Millisecond == 0:
+		return fmt.Sprintf("%d * time.Millisecond", d/time.Millisecond)
+	default:
+		return fmt.Sprintf("time.Duration(%d)", int64(d))
+	}
+}
+
+// input: map[string]interface{}{"Endpoint": EndpointData, "Client": *design.ClientExpr}
+const clientMiddlewareT = `{{ printf "%sMiddleware returns a go-kit endpoint middleware that applies the client timeout, retries and circuit breaker defined in the design of the %s service %s method." .Endpoint.Method.VarName .Endpoint.ServiceName .Endpoint.Method.Name | comment }}
+func {{ .Endpoint.Method.VarName }}Middleware() endpoint.Middleware {
+{{- if .Client.Breaker }}
+	breaker := {{ .Endpoint.Method.VarName }}Breaker()
+{{- end }}
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+{{- if or .Client.Retries .Client.Timeout }}
+	{{- if .Client.Breaker }}
+		next = breaker(next)
+	{{- end }}
+{{- end }}
+{{- if .Client.Retries }}
+		return lb.Retry({{ add .Client.Retries 1 }}, {{ duration .Client.MaxDuration }}, lb.NewRoundRobin(sd.FixedEndpointer{next}))
+{{- else if .Client.Timeout }}
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, {{ duration .Client.Timeout }})
+			defer cancel()
+			return next(ctx, req)
+		}
+{{- else }}
+		return breaker(next)
+{{- end }}
+	}
+}
+`
+
+// input: map[string]interface{}{"Endpoint": EndpointData, "Client": *design.ClientExpr}
+const clientBreakerT = `{{ printf "%sBreaker returns the go-kit circuit breaker middleware defined in the design of the %s service %s method." .Endpoint.Method.VarName .Endpoint.ServiceName .Endpoint.Method.Name | comment }}
+func {{ .Endpoint.Method.VarName }}Breaker() endpoint.Middleware {
+{{- with .Client.Breaker }}
+	{{- if eq .Kind "hystrix" }}
+	hystrix.ConfigureCommand({{ printf "%q" $.Client.Name }}, hystrix.CommandConfig{
+		Timeout:                {{ millis $.Client.MaxDuration }},
+		ErrorPercentThreshold:  {{ .ErrorThreshold }},
+		RequestVolumeThreshold: {{ .VolumeThreshold }},
+		SleepWindow:            {{ millis .SleepWindow }},
+	})
+	return circuitbreaker.Hystrix({{ printf "%q" $.Client.Name }})
+	{{- else }}
+	return circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    {{ printf "%q" $.Client.Name }},
+		Timeout: {{ duration .SleepWindow }},
+		ReadyToTrip: func(c gobreaker.Counts) bool {
+			return c.Requests >= {{ .VolumeThreshold }} && c.TotalFailures*100 >= {{ .ErrorThreshold }}*c.Requests
+		},
+	}))
+	{{- end }}
+{{- end }}
+}
+`
@@ -0,0 +1,57 @@
+package goakit
+
+import (
+	"testing"
+	"time"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestClientMiddlewareFiles(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.ClientSettingsDSL)
+	fs := ClientMiddlewareFiles(httpdesign.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	testCode(t, fs[0], "goakit-client-middleware", []string{
+		testdata.TimeoutMethodClientMiddlewareCode,
+		testdata.RetryMethodClientMiddlewareCode,
+		testdata.GobreakerMethodClientMiddlewareCode,
+		testdata.HystrixMethodClientMiddlewareCode,
+	})
+	testCode(t, fs[0], "goakit-client-breaker", []string{
+		testdata.GobreakerMethodClientBreakerCode,
+		testdata.HystrixMethodClientBreakerCode,
+	})
+
+	fs = ClientFiles("", httpdesign.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d client files, expected 1", len(fs))
+	}
+	testCode(t, fs[0], "goakit-client-new", []string{testdata.ClientServiceGoakitClientNewCode})
+}
+
+func TestClientMiddlewareFilesNoSettings(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SimpleServiceDSL)
+	fs := ClientMiddlewareFiles(httpdesign.Root)
+	if len(fs) != 0 {
+		t.Errorf("got %d files, expected 0", len(fs))
+	}
+}
+
+func TestDurationCode(t *testing.T) {
+	cases := map[time.Duration]string{
+		2 * time.Hour:           "2 * time.Hour",
+		90 * time.Minute:        "90 * time.Minute",
+		30 * time.Second:        "30 * time.Second",
+		1500 * time.Millisecond: "1500 * time.Millisecond",
+		42:                      "time.Duration(42)",
+	}
+	for d, expected := range cases {
+		if code := durationCode(d); code != expected {
+			t.Errorf("durationCode(%v): got %q, expected %q", d, code, expected)
+		}
+	}
+}
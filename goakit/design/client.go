@@ -0,0 +1,119 @@
+package design
+
+import (
+	"fmt"
+	"time"
+
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+const (
+	// GobreakerKind identifies the circuit breaker implemented with
+	// github.com/sony/gobreaker.
+	GobreakerKind = "gobreaker"
+	// HystrixKind identifies the circuit breaker implemented with
+	// github.com/afex/hystrix-go.
+	HystrixKind = "hystrix"
+
+	// DefaultTimeout is the duration allotted to a method call and its
+	// retries when the design does not define a timeout.
+	DefaultTimeout = 10 * time.Second
+)
+
+type (
+	// ClientExpr describes the resilience settings of the go-kit client of
+	// a method.
+	ClientExpr struct {
+		// Method is the method the settings apply to.
+		Method *goadesign.MethodExpr
+		// Idempotent is true if the method may be retried safely.
+		Idempotent bool
+		// Timeout is the maximum duration of a method call including the
+		// retries, zero if there is no timeout.
+		Timeout time.Duration
+		// Retries is the maximum number of retries of a failed call.
+		Retries int
+		// Breaker is the circuit breaker configuration, nil if the calls
+		// are not protected by a circuit breaker.
+		Breaker *BreakerExpr
+	}
+
+	// BreakerExpr describes a circuit breaker.
+	BreakerExpr struct {
+		// Kind is the circuit breaker implementation, GobreakerKind or
+		// HystrixKind.
+		Kind string
+		// ErrorThreshold is the percentage of failed calls that opens the
+		// circuit.
+		ErrorThreshold int
+		// VolumeThreshold is the minimum number of calls before the
+		// circuit can open.
+		VolumeThreshold int
+		// SleepWindow is the duration the circuit stays open before
+		// letting calls through again.
+		SleepWindow time.Duration
+		// Parent is the client expression.
+		Parent *ClientExpr
+	}
+)
+
+// Name returns the name of the client used to identify the circuit breaker,
+// "<service>.<method>".
+func (c *ClientExpr) Name() string {
+	return fmt.Sprintf("%s.%s", c.Method.Service.Name, c.Method.Name)
+}
+
+// MaxDuration returns the duration allotted to a method call including the
+// retries, DefaultTimeout if the design does not define a timeout.
+func (c *ClientExpr) MaxDuration() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+// HasMiddleware returns true if the settings require wrapping the client
+// endpoint, that is if they define a timeout, retries or a circuit breaker.
+func (c *ClientExpr) HasMiddleware() bool {
+	return c.Timeout > 0 || c.Retries > 0 || c.Breaker != nil
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (c *ClientExpr) EvalName() string {
+	return fmt.Sprintf("go-kit client of %s", c.Method.EvalName())
+}
+
+// Validate ensures the client settings are consistent.
+func (c *ClientExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if c.Retries < 0 {
+		verr.Add(c, "number of retries cannot be negative")
+	}
+	if c.Retries > 0 && !c.Idempotent {
+		verr.Add(c, "only idempotent methods can be retried, use Idempotent to mark the method as idempotent")
+	}
+	if c.Timeout < 0 {
+		verr.Add(c, "timeout cannot be negative")
+	}
+	if b := c.Breaker; b != nil {
+		if b.Kind != GobreakerKind && b.Kind != HystrixKind {
+			verr.Add(c, "invalid circuit breaker %q, must be %q or %q", b.Kind, GobreakerKind, HystrixKind)
+		}
+		if b.ErrorThreshold < 1 || b.ErrorThreshold > 100 {
+			verr.Add(c, "circuit breaker error threshold must be a percentage between 1 and 100")
+		}
+		if b.VolumeThreshold < 1 {
+			verr.Add(c, "circuit breaker volume threshold must be positive")
+		}
+		if b.SleepWindow <= 0 {
+			verr.Add(c, "circuit breaker sleep window must be positive")
+		}
+	}
+	return verr
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (b *BreakerExpr) EvalName() string {
+	return fmt.Sprintf("circuit breaker of %s", b.Parent.EvalName())
+}
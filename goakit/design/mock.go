@@ -0,0 +1,31 @@
+package design
+
+import (
+	"fmt"
+
+	goadesign "goa.design/goa/design"
+)
+
+type (
+	// MockExpr describes the mock implementation of a service.
+	MockExpr struct {
+		// Service is the mocked service.
+		Service *goadesign.ServiceExpr
+	}
+)
+
+// Mock returns the mock implementation settings of the given service, nil if
+// the design does not enable it.
+func (r *RootExpr) Mock(svc *goadesign.ServiceExpr) *MockExpr {
+	for _, m := range r.MockServices {
+		if m.Service == svc {
+			return m
+		}
+	}
+	return nil
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (m *MockExpr) EvalName() string {
+	return fmt.Sprintf("mock implementation of %s", m.Service.EvalName())
+}
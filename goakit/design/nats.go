@@ -0,0 +1,86 @@
+package design
+
+import (
+	"fmt"
+	"strings"
+
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+type (
+	// NATSExpr describes the NATS transport of a service.
+	NATSExpr struct {
+		// Service is the service served over NATS.
+		Service *goadesign.ServiceExpr
+		// Methods lists the NATS settings of the service methods that
+		// define any.
+		Methods []*NATSMethodExpr
+	}
+
+	// NATSMethodExpr describes the NATS settings of a method.
+	NATSMethodExpr struct {
+		// Method is the method the settings apply to.
+		Method *goadesign.MethodExpr
+		// Subject is the subject of the method requests, empty to use
+		// the default subject.
+		Subject string
+		// Parent is the service NATS expression.
+		Parent *NATSExpr
+	}
+)
+
+// NATS returns the NATS transport of the given service, nil if the design
+// does not enable it.
+func (r *RootExpr) NATS(svc *goadesign.ServiceExpr) *NATSExpr {
+	for _, n := range r.NATSServices {
+		if n.Service == svc {
+			return n
+		}
+	}
+	return nil
+}
+
+// Subject returns the subject of the requests made to the given method,
+// "<service>.<method>" unless the design overrides it.
+func (n *NATSExpr) Subject(method string) string {
+	for _, m := range n.Methods {
+		if m.Method.Name == method && m.Subject != "" {
+			return m.Subject
+		}
+	}
+	return fmt.Sprintf("%s.%s", n.Service.Name, method)
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (n *NATSExpr) EvalName() string {
+	return fmt.Sprintf("NATS transport of %s", n.Service.EvalName())
+}
+
+// Validate ensures the service defines an HTTP transport, the NATS codecs
+// being built from the HTTP codecs, and that the method subjects are valid
+// and distinct.
+func (n *NATSExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if httpdesign.Root.Service(n.Service.Name) == nil {
+		verr.Add(n, "NATS transport requires the service to define an HTTP transport")
+	}
+	subjects := make(map[string]string)
+	for _, m := range n.Service.Methods {
+		s := n.Subject(m.Name)
+		if strings.ContainsAny(s, " \t\r\n*>") || strings.HasPrefix(s, ".") || strings.HasSuffix(s, ".") || strings.Contains(s, "..") {
+			verr.Add(n, "invalid subject %q of method %q, subjects must be dot separated tokens without wildcards", s, m.Name)
+		}
+		if other, ok := subjects[s]; ok {
+			verr.Add(n, "methods %q and %q use the same subject %q", other, m.Name, s)
+		}
+		subjects[s] = m.Name
+	}
+	return verr
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (m *NATSMethodExpr) EvalName() string {
+	return fmt.Sprintf("NATS settings of %s", m.Method.EvalName())
+}
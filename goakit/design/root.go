@@ -0,0 +1,79 @@
+package design
+
+import (
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// Root is the design root expression.
+var Root = &RootExpr{}
+
+type (
+	// RootExpr keeps track of the go-kit client settings, NATS transports
+	// and mock implementations defined in the design.
+	RootExpr struct {
+		// Clients lists the method client settings in the order they are
+		// defined.
+		Clients []*ClientExpr
+		// NATSServices lists the NATS transports of the services in the
+		// order they are defined.
+		NATSServices []*NATSExpr
+		// MockServices lists the services that enable the generation of
+		// a mock implementation in the order they are defined.
+		MockServices []*MockExpr
+	}
+)
+
+// Register design root with eval engine.
+func init() {
+	eval.Register(Root)
+}
+
+// EvalName returns the name used in error messages.
+func (r *RootExpr) EvalName() string {
+	return "goakit plugin"
+}
+
+// WalkSets iterates over the method client settings, the service NATS
+// transports and the service mock implementations.
+func (r *RootExpr) WalkSets(walk eval.SetWalker) {
+	clients := make(eval.ExpressionSet, 0, len(r.Clients))
+	for _, c := range r.Clients {
+		clients = append(clients, c)
+	}
+	walk(clients)
+	natss := make(eval.ExpressionSet, 0, len(r.NATSServices))
+	for _, n := range r.NATSServices {
+		natss = append(natss, n)
+	}
+	walk(natss)
+	mocks := make(eval.ExpressionSet, 0, len(r.MockServices))
+	for _, m := range r.MockServices {
+		mocks = append(mocks, m)
+	}
+	walk(mocks)
+}
+
+// DependsOn tells the eval engine to run the goa DSL first.
+func (r *RootExpr) DependsOn() []eval.Root {
+	return []eval.Root{httpdesign.Root}
+}
+
+// Packages returns the import path to the Go packages that make
+// up the DSL. This is used to skip frames that point to files
+// in these packages when computing the location of errors.
+func (r *RootExpr) Packages() []string {
+	return []string{"goa.design/plugins/goakit/dsl"}
+}
+
+// Client returns the client settings of the given method of the given
+// service, nil if the design does not define any.
+func (r *RootExpr) Client(svc, method string) *ClientExpr {
+	for i := len(r.Clients) - 1; i >= 0; i-- {
+		c := r.Clients[i]
+		if c.Method.Service.Name == svc && c.Method.Name == method {
+			return c
+		}
+	}
+	return nil
+}
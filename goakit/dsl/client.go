@@ -0,0 +1,193 @@
+package dsl
+
+import (
+	"time"
+
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	"goa.design/goa/http/dsl"
+	"goa.design/plugins/goakit/design"
+)
+
+// Idempotent marks the method as idempotent, that is safe to call multiple
+// times with the same payload. Only idempotent methods are retried by the
+// generated go-kit clients.
+//
+// Idempotent must appear in a Method expression.
+//
+// Idempotent takes no argument.
+//
+// Example:
+//
+//    Method("read", func() {
+//        Idempotent()
+//        Retry(2)
+//    })
+//
+func Idempotent() {
+	if c := client(); c != nil {
+		c.Idempotent = true
+	}
+}
+
+// Timeout sets the maximum duration of the calls made by the generated go-kit
+// clients to the method. The duration is the total budget of a call: it
+// includes all the attempts made when the method defines retries and the
+// attempts are not bounded individually, an attempt that takes the whole
+// duration leaves no time for the retries.
+//
+// When used in an Error expression without argument Timeout qualifies the
+// error as describing errors due to timeouts, see the goa DSL.
+//
+// Timeout must appear in a Method or Error expression.
+//
+// Timeout accepts a single duration argument when used in Method.
+//
+// Example:
+//
+//    Method("read", func() {
+//        Timeout(2 * time.Second)
+//    })
+//
+func Timeout(d ...time.Duration) {
+	if len(d) == 0 {
+		dsl.Timeout()
+		return
+	}
+	if len(d) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	if c := client(); c != nil {
+		c.Timeout = d[0]
+	}
+}
+
+// Retry sets the maximum number of times the generated go-kit clients retry a
+// failed call to the method. The method must be marked as idempotent with
+// Idempotent. The retries must complete within the duration set with Timeout
+// or within 10 seconds if there is no timeout.
+//
+// Retry must appear in a Method expression.
+//
+// Retry accepts a single argument: the maximum number of retries.
+//
+// Example:
+//
+//    Method("read", func() {
+//        Idempotent()
+//        Retry(2) // Make up to 3 attempts
+//    })
+//
+func Retry(max int) {
+	if c := client(); c != nil {
+		c.Retries = max
+	}
+}
+
+// CircuitBreaker protects the calls made by the generated go-kit clients to
+// the method with a circuit breaker. The circuit opens when the percentage of
+// failed calls reaches the error threshold once the number of calls reaches
+// the volume threshold. The circuit then stays open for the duration of the
+// sleep window during which calls fail immediately. The thresholds default to
+// 50% and 20 calls and the sleep window to 5 seconds.
+//
+// CircuitBreaker must appear in a Method expression.
+//
+// CircuitBreaker accepts the name of the circuit breaker implementation as
+// first argument, "gobreaker" (github.com/sony/gobreaker) or "hystrix"
+// (github.com/afex/hystrix-go), and an optional DSL as second argument.
+//
+// Example:
+//
+//    Method("read", func() {
+//        CircuitBreaker("gobreaker", func() {
+//            ErrorThreshold(25)            // Open circuit when 25% of calls fail
+//            VolumeThreshold(10)           // once there are at least 10 calls
+//            SleepWindow(30 * time.Second) // Keep circuit open for 30s
+//        })
+//    })
+//
+func CircuitBreaker(kind string, fn ...func()) {
+	c := client()
+	if c == nil {
+		return
+	}
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	b := &design.BreakerExpr{
+		Kind:            kind,
+		ErrorThreshold:  50,
+		VolumeThreshold: 20,
+		SleepWindow:     5 * time.Second,
+		Parent:          c,
+	}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], b) {
+			return
+		}
+	}
+	c.Breaker = b
+}
+
+// ErrorThreshold sets the percentage of failed calls that opens the circuit.
+//
+// ErrorThreshold must appear in a CircuitBreaker expression.
+//
+// ErrorThreshold accepts a single argument: a percentage between 1 and 100.
+func ErrorThreshold(percent int) {
+	if b, ok := eval.Current().(*design.BreakerExpr); ok {
+		b.ErrorThreshold = percent
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// VolumeThreshold sets the minimum number of calls before the circuit can
+// open.
+//
+// VolumeThreshold must appear in a CircuitBreaker expression.
+//
+// VolumeThreshold accepts a single argument: the number of calls.
+func VolumeThreshold(calls int) {
+	if b, ok := eval.Current().(*design.BreakerExpr); ok {
+		b.VolumeThreshold = calls
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// SleepWindow sets the duration the circuit stays open before letting calls
+// through again.
+//
+// SleepWindow must appear in a CircuitBreaker expression.
+//
+// SleepWindow accepts a single argument: the duration.
+func SleepWindow(d time.Duration) {
+	if b, ok := eval.Current().(*design.BreakerExpr); ok {
+		b.SleepWindow = d
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// client returns the client settings of the current method, creating and
+// registering them if needed. client reports an error and returns nil if the
+// current expression is not a method.
+func client() *design.ClientExpr {
+	m, ok := eval.Current().(*goadesign.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	for _, c := range design.Root.Clients {
+		if c.Method == m {
+			return c
+		}
+	}
+	c := &design.ClientExpr{Method: m}
+	design.Root.Clients = append(design.Root.Clients, c)
+	return c
+}
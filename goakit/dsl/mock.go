@@ -0,0 +1,34 @@
+package dsl
+
+import (
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	"goa.design/plugins/goakit/design"
+)
+
+// Mock enables the generation of the mock implementation of a service. The
+// goakit example command generates the mock implementations in the files
+// <service>_mock.go, the mock methods return the examples defined in the
+// design. The example server uses the mock implementations when started with
+// the -mock flag.
+//
+// Mock must appear in a Service expression.
+//
+// Mock takes no argument.
+//
+// Example:
+//
+//    Service("calc", func() {
+//        Mock()
+//    })
+//
+func Mock() {
+	s, ok := eval.Current().(*goadesign.ServiceExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if design.Root.Mock(s) == nil {
+		design.Root.MockServices = append(design.Root.MockServices, &design.MockExpr{Service: s})
+	}
+}
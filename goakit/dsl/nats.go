@@ -0,0 +1,79 @@
+package dsl
+
+import (
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	"goa.design/plugins/goakit/design"
+)
+
+// NATS enables the go-kit NATS transport of a service or defines the NATS
+// settings of a method. goakit generates the NATS subscribers and publishers
+// of the services that enable the transport. The requests made to a method
+// use the subject "<service>.<method>" unless the method overrides it with
+// Subject. The NATS codecs use the HTTP codecs so the service must also define
+// an HTTP transport.
+//
+// NATS must appear in a Service or Method expression. NATS must appear in the
+// Service expression for the NATS expressions of its methods to apply.
+//
+// NATS accepts an optional DSL as argument.
+//
+// Example:
+//
+//    Service("calc", func() {
+//        NATS()
+//        Method("add", func() {
+//            NATS(func() {
+//                Subject("calc.sum")
+//            })
+//        })
+//    })
+//
+func NATS(fn ...func()) {
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	switch e := eval.Current().(type) {
+	case *goadesign.ServiceExpr:
+		n := design.Root.NATS(e)
+		if n == nil {
+			n = &design.NATSExpr{Service: e}
+			design.Root.NATSServices = append(design.Root.NATSServices, n)
+		}
+		if len(fn) == 1 {
+			eval.Execute(fn[0], n)
+		}
+	case *goadesign.MethodExpr:
+		n := design.Root.NATS(e.Service)
+		if n == nil {
+			eval.ReportError("NATS must be enabled in service %q to define the NATS settings of its methods", e.Service.Name)
+			return
+		}
+		m := &design.NATSMethodExpr{Method: e, Parent: n}
+		if len(fn) == 1 && !eval.Execute(fn[0], m) {
+			return
+		}
+		n.Methods = append(n.Methods, m)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Subject sets the subject of the NATS requests made to the method.
+//
+// Subject must appear in a method NATS expression.
+//
+// Subject accepts a single argument: the subject made of dot separated tokens.
+func Subject(subject string) {
+	m, ok := eval.Current().(*design.NATSMethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if subject == "" {
+		eval.ReportError("subject cannot be empty")
+		return
+	}
+	m.Subject = subject
+}
@@ -0,0 +1,85 @@
+package dsl
+
+import (
+	secdesign "goa.design/plugins/security/design"
+	secdsl "goa.design/plugins/security/dsl"
+)
+
+// The functions below expose the security plugin DSL in place of the goa core
+// security DSL: goakit enforces the security requirements defined with the
+// security plugin DSL. Defining them in this package also keeps the aliaser
+// tool from generating aliases to the core security functions of the same
+// name.
+
+// BasicAuthSecurity defines a basic authentication security scheme. See
+// goa.design/plugins/security/dsl.BasicAuthSecurity.
+func BasicAuthSecurity(name string, fn ...func()) *secdesign.SchemeExpr {
+	return secdsl.BasicAuthSecurity(name, fn...)
+}
+
+// APIKeySecurity defines an API key security scheme. See
+// goa.design/plugins/security/dsl.APIKeySecurity.
+func APIKeySecurity(name string, fn ...func()) *secdesign.SchemeExpr {
+	return secdsl.APIKeySecurity(name, fn...)
+}
+
+// OAuth2Security defines an OAuth2 security scheme. See
+// goa.design/plugins/security/dsl.OAuth2Security.
+func OAuth2Security(name string, fn ...func()) *secdesign.SchemeExpr {
+	return secdsl.OAuth2Security(name, fn...)
+}
+
+// JWTSecurity defines a JSON Web Token security scheme. See
+// goa.design/plugins/security/dsl.JWTSecurity.
+func JWTSecurity(name string, fn ...func()) *secdesign.SchemeExpr {
+	return secdsl.JWTSecurity(name, fn...)
+}
+
+// In defines the location of the credentials of an API key or JWT security
+// scheme. See goa.design/plugins/security/dsl.In.
+func In(location, name string) {
+	secdsl.In(location, name)
+}
+
+// AuthorizationCodeFlow defines an authorizationCode OAuth2 flow. See
+// goa.design/plugins/security/dsl.AuthorizationCodeFlow.
+func AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL string) {
+	secdsl.AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL)
+}
+
+// ImplicitFlow defines an implicit OAuth2 flow. See
+// goa.design/plugins/security/dsl.ImplicitFlow.
+func ImplicitFlow(authorizationURL, refreshURL string) {
+	secdsl.ImplicitFlow(authorizationURL, refreshURL)
+}
+
+// PasswordFlow defines a Resource Owner Password Credentials OAuth2 flow. See
+// goa.design/plugins/security/dsl.PasswordFlow.
+func PasswordFlow(tokenURL, refreshURL string) {
+	secdsl.PasswordFlow(tokenURL, refreshURL)
+}
+
+// ClientCredentialsFlow defines a clientCredentials OAuth2 flow. See
+// goa.design/plugins/security/dsl.ClientCredentialsFlow.
+func ClientCredentialsFlow(tokenURL, refreshURL string) {
+	secdsl.ClientCredentialsFlow(tokenURL, refreshURL)
+}
+
+// Scope defines a scope supported by an OAuth2 or JWT security scheme or
+// lists the scopes required by a security requirement. See
+// goa.design/plugins/security/dsl.Scope.
+func Scope(name string, desc ...string) {
+	secdsl.Scope(name, desc...)
+}
+
+// Security defines the security requirements of the API, a service or a
+// method. See goa.design/plugins/security/dsl.Security.
+func Security(args ...interface{}) {
+	secdsl.Security(args...)
+}
+
+// NoSecurity removes the security requirements of a service or method. See
+// goa.design/plugins/security/dsl.NoSecurity.
+func NoSecurity() {
+	secdsl.NoSecurity()
+}
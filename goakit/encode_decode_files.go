@@ -7,6 +7,7 @@ import (
 	"goa.design/goa/codegen"
 	httpcodegen "goa.design/goa/http/codegen"
 	httpdesign "goa.design/goa/http/design"
+	secdesign "goa.design/plugins/security/design"
 )
 
 // EncodeDecodeFiles produces a set of go-kit transport encoders and decoders
@@ -26,7 +27,7 @@ func EncodeDecodeFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File
 // and decoding logic.
 func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitserver", "encode_decode.go")
-	data := httpcodegen.HTTPServices.Get(svc.Name())
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
 	title := fmt.Sprintf("%s go-kit HTTP server encoders and decoders", svc.Name())
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
@@ -37,6 +38,7 @@ func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 			{Path: "goa.design/goa", Name: "goa"},
 			{Path: "goa.design/goa/http", Name: "goahttp"},
 			{Path: genpkg + "/http/" + data.Service.Name + "/server"},
+			{Path: "goa.design/plugins/goakit/kitauth"},
 		}),
 	}
 
@@ -56,10 +58,14 @@ func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 		}
 
 		if len(e.Errors) > 0 {
+			secured := len(secdesign.Requirements(data.Service.Name, e.Method.Name)) > 0
+			fm := codegen.TemplateFuncs()
+			fm["secured"] = func() bool { return secured }
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "goakit-error-encoder",
-				Source: errorEncoderT,
-				Data:   e,
+				Name:    "goakit-error-encoder",
+				Source:  errorEncoderT,
+				Data:    e,
+				FuncMap: fm,
 			})
 		}
 	}
@@ -72,7 +78,7 @@ func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 func clientEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "encode_decode.go")
 	title := fmt.Sprintf("%s go-kit HTTP client encoders and decoders", svc.Name())
-	data := httpcodegen.HTTPServices.Get(svc.Name())
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "client", []*codegen.ImportSpec{
 			{Path: "context"},
@@ -116,9 +122,15 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) kithttp.
 `
 
 // input: EndpointData
-const requestDecoderT = `{{ printf "%s returns a go-kit DecodeRequestFunc suitable for decoding %s %s requests." .RequestDecoder .ServiceName .Method.Name | comment }}
+const requestDecoderT = `{{ if .MultipartRequestDecoder -}}
+{{ printf "%s returns a go-kit DecodeRequestFunc suitable for decoding %s %s multipart requests. The request parts are decoded with the user provided function %s." .RequestDecoder .ServiceName .Method.Name .MultipartRequestDecoder.VarName | comment }}
+func {{ .RequestDecoder }}(mux goahttp.Muxer, {{ .MultipartRequestDecoder.VarName }} server.{{ .MultipartRequestDecoder.FuncName }}) kithttp.DecodeRequestFunc {
+	dec := server.{{ .RequestDecoder }}(mux, server.{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}))
+{{- else -}}
+{{ printf "%s returns a go-kit DecodeRequestFunc suitable for decoding %s %s requests." .RequestDecoder .ServiceName .Method.Name | comment }}
 func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) kithttp.DecodeRequestFunc {
 	dec := server.{{ .RequestDecoder }}(mux, decoder)
+{{- end }}
 	return func(ctx context.Context, r *http.Request) (interface{}, error) {
 		r = r.WithContext(ctx)
 		return dec(r)
@@ -134,10 +146,21 @@ const responseEncoderT = `{{ printf "%s returns a go-kit EncodeResponseFunc suit
 `
 
 // input: EndpointData
-const errorEncoderT = `{{ printf "%s returns a go-kit EncodeResponseFunc suitable for encoding errors returned by the %s %s endpoint." .ErrorEncoder .ServiceName .Method.Name | comment }}
+const errorEncoderT = `{{ printf "%s returns a go-kit EncodeResponseFunc suitable for encoding errors returned by the %s %s endpoint. The errors wrapped with New%sError are unwrapped before being encoded." .ErrorEncoder .ServiceName .Method.Name .Method.VarName | comment }}
  func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
  	enc := server.{{ .ErrorEncoder }}(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+{{- if secured }}
+		// The authorization errors are not defined in the design, go-kit
+		// encodes them using their status code and headers.
+		if _, ok := err.(*kitauth.Error); ok {
+			kithttp.DefaultErrorEncoder(ctx, err, w)
+			return
+		}
+{{- end }}
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
@@ -47,6 +47,24 @@ func TestServerEncodeDecode(t *testing.T) {
 				"goakit-error-encoder":    []string{testdata.Endpoint1GoakitErrorEncoderCode, testdata.Endpoint2GoakitErrorEncoderCode},
 			},
 		},
+		"multipart": {
+			DSL: testdata.MultipartDSL,
+			Code: map[string][]string{
+				"goakit-response-encoder": []string{testdata.MultipartMethodGoakitResponseEncoderCode},
+				"goakit-request-decoder":  []string{testdata.MultipartMethodGoakitRequestDecoderCode},
+				"goakit-error-encoder":    []string{},
+			},
+		},
+		"secured": {
+			DSL: testdata.SecurityDSL,
+			Code: map[string][]string{
+				"goakit-error-encoder": []string{
+					testdata.SecureMethodGoakitErrorEncoderCode,
+					testdata.MultiMethodGoakitErrorEncoderCode,
+					testdata.UnsecureMethodGoakitErrorEncoderCode,
+				},
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,56 @@
+package goakit
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+)
+
+// addEndpointsApply adds the Apply and UseGoa methods to the Endpoints
+// struct defined in the goa generated endpoints file of each service.
+func addEndpointsApply(files []*codegen.File) {
+	for _, svc := range design.Root.Services {
+		path := filepath.Join(codegen.Gendir, codegen.SnakeCase(svc.Name), "endpoints.go")
+		for _, f := range files {
+			if f.Path != path {
+				continue
+			}
+			data := service.Services.Get(svc.Name)
+			ss := []*codegen.SectionTemplate{
+				{Name: "goakit-endpoints-apply", Source: endpointsApplyT, Data: data},
+				{Name: "goakit-endpoints-use-goa", Source: endpointsUseGoaT, Data: data},
+			}
+			// Add the methods right after the Use method.
+			idx := len(f.SectionTemplates)
+			for i, st := range f.SectionTemplates {
+				if st.Name == "endpoints-use" {
+					idx = i + 1
+					break
+				}
+			}
+			f.SectionTemplates = append(f.SectionTemplates[:idx], append(ss, f.SectionTemplates[idx:]...)...)
+			codegen.AddImport(f.SectionTemplates[0], &codegen.ImportSpec{Path: "github.com/go-kit/kit/endpoint"})
+			codegen.AddImport(f.SectionTemplates[0], &codegen.ImportSpec{Path: "goa.design/plugins/goakit/kitendpoint"})
+		}
+	}
+}
+
+// input: service.Data
+const endpointsApplyT = `{{ printf "Apply applies the middlewares returned by m to the %q service endpoints. m is called with the name of the method implemented by each endpoint as defined in the design. The endpoints for which m returns nil are left unchanged." .Name | comment }}
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+{{- range .Methods }}
+	if mw := m({{ printf "%q" .Name }}); mw != nil {
+		e.{{ .VarName }} = mw(e.{{ .VarName }})
+	}
+{{- end }}
+}
+`
+
+// input: service.Data
+const endpointsUseGoaT = `{{ printf "UseGoa applies the goa endpoint middleware m to the %q service endpoints so that the middlewares written for the services generated without goakit may be reused." .Name | comment }}
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+`
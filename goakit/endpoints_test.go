@@ -0,0 +1,74 @@
+package goakit
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestEndpointsApply(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code string
+	}{
+		"simple-service":  {testdata.SimpleServiceDSL, testdata.SimpleServiceEndpointsApplyCode},
+		"multi-endpoints": {testdata.MultiEndpointDSL, testdata.MultiEndpointServiceEndpointsApplyCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			roots := []eval.Root{goadesign.Root, httpdesign.Root}
+			files, err := Generate("", roots, generateFiles(t, roots))
+			if err != nil {
+				t.Fatalf("generate error: %v", err)
+			}
+			var sections []*codegen.SectionTemplate
+			for _, f := range files {
+				sections = append(sections, f.Section("goakit-endpoints-apply")...)
+			}
+			if len(sections) != 1 {
+				t.Fatalf("got %d sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestEndpointsUseGoa(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code string
+	}{
+		"simple-service":  {testdata.SimpleServiceDSL, testdata.SimpleServiceEndpointsUseGoaCode},
+		"multi-endpoints": {testdata.MultiEndpointDSL, testdata.MultiEndpointServiceEndpointsUseGoaCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			roots := []eval.Root{goadesign.Root, httpdesign.Root}
+			files, err := Generate("", roots, generateFiles(t, roots))
+			if err != nil {
+				t.Fatalf("generate error: %v", err)
+			}
+			var sections []*codegen.SectionTemplate
+			for _, f := range files {
+				sections = append(sections, f.Section("goakit-endpoints-use-goa")...)
+			}
+			if len(sections) != 1 {
+				t.Fatalf("goakit-endpoints-use-goa: got %d sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("goakit-endpoints-use-goa: invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ErrorFiles produces the files defining the go-kit friendly wrappers of the
+// errors defined in the design. The wrappers implement the go-kit StatusCoder
+// and Headerer interfaces as well as json.Marshaler using the HTTP responses
+// defined in the design. Services whose methods define no error are skipped.
+func ErrorFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		if f := errorFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// errorFile returns the file defining the error wrappers for the given service
+// or nil if the service methods define no error.
+func errorFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	var eps []*httpcodegen.EndpointData
+	for _, e := range data.Endpoints {
+		if len(e.Errors) > 0 {
+			eps = append(eps, e)
+		}
+	}
+	if len(eps) == 0 {
+		return nil
+	}
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitserver", "errors.go")
+	title := fmt.Sprintf("%s go-kit HTTP server errors", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "net/http"},
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/server"},
+		}),
+		{
+			Name:   "goakit-error",
+			Source: errorT,
+			Data:   data,
+		},
+	}
+	for _, e := range eps {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-error-wrapper",
+			Source: errorWrapperT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const errorT = `{{ printf "Error wraps an error defined in the design of the %s service so that it implements the go-kit kithttp.StatusCoder and kithttp.Headerer interfaces as well as json.Marshaler. kithttp.DefaultErrorEncoder uses these interfaces to write the status code, headers and body defined in the design." .Service.Name | comment }}
+type Error struct {
+	// Name is the name of the error as defined in the design.
+	Name string
+	// Status is the HTTP status code of the error response.
+	Status int
+	// Err is the wrapped error.
+	Err error
+	// body is the error response body, nil if the design does not define
+	// one.
+	body interface{}
+}
+
+// Error returns the message of the wrapped error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// ErrorName returns the name of the error as defined in the design.
+func (e *Error) ErrorName() string {
+	return e.Name
+}
+
+// StatusCode returns the HTTP status code of the error response.
+func (e *Error) StatusCode() int {
+	return e.Status
+}
+
+// Headers returns the headers of the error response.
+func (e *Error) Headers() http.Header {
+	return http.Header{"goa-error": []string{e.Name}}
+}
+
+// MarshalJSON returns the JSON encoding of the error response body. The
+// wrapped error is encoded if the design does not define a body.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e.body == nil {
+		return json.Marshal(e.Err)
+	}
+	return json.Marshal(e.body)
+}
+`
+
+// input: EndpointData
+const errorWrapperT = `{{ printf "New%sError wraps err into an *Error if it is one of the errors defined in the design of the %s service %s method. It returns err unchanged otherwise." .Method.VarName .ServiceName .Method.Name | comment }}
+func New{{ .Method.VarName }}Error(err error) error {
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	en, ok := err.(server.ErrorNamer)
+	if !ok {
+		return err
+	}
+	switch en.ErrorName() {
+{{- range $gerr := .Errors }}
+	{{- range $err := .Errors }}
+	case {{ printf "%q" .Name }}:
+		e := &Error{Name: {{ printf "%q" .Name }}, Status: {{ .Response.StatusCode }}, Err: err}
+		{{- with .Response.ServerBody }}{{ with .Init }}
+		e.body = server.{{ .Name }}(err.({{ $err.Ref }}))
+		{{- end }}{{ end }}
+		return e
+	{{- end }}
+{{- end }}
+	}
+	return err
+}
+
+{{ printf "%sErrorMiddleware is a go-kit endpoint middleware that wraps the errors returned by the %s service %s endpoint with New%sError. It should be the innermost middleware so that the other middlewares observe the wrapped errors." .Method.VarName .ServiceName .Method.Name .Method.VarName | comment }}
+func {{ .Method.VarName }}ErrorMiddleware(e endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := e(ctx, req)
+		if err != nil {
+			return nil, New{{ .Method.VarName }}Error(err)
+		}
+		return res, nil
+	}
+}
+`
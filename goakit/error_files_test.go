@@ -0,0 +1,50 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestErrorFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code map[string][]string
+	}{
+		"with-error": {
+			DSL: testdata.WithErrorDSL,
+			Code: map[string][]string{
+				"goakit-error":         []string{testdata.WithErrorServiceGoakitErrorCode},
+				"goakit-error-wrapper": []string{testdata.WithErrorMethodGoakitErrorWrapperCode},
+			},
+		},
+		"multi-endpoints": {
+			DSL: testdata.MultiEndpointDSL,
+			Code: map[string][]string{
+				"goakit-error-wrapper": []string{testdata.Endpoint1GoakitErrorWrapperCode, testdata.Endpoint2GoakitErrorWrapperCode},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := ErrorFiles("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			for sec, secCode := range c.Code {
+				testCode(t, fs[0], sec, secCode)
+			}
+		})
+	}
+}
+
+func TestErrorFilesNoError(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SimpleServiceDSL)
+	fs := ErrorFiles("", httpdesign.Root)
+	if len(fs) != 0 {
+		t.Errorf("got %d files, expected 0", len(fs))
+	}
+}
@@ -0,0 +1,194 @@
+package goakit
+
+import (
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ExampleCLIFiles returns an example command line client that calls the
+// service methods using the go-kit HTTP clients. The client uses the goa
+// generated cli package to parse the method flags and build the payloads so
+// that the go-kit encoders and decoders are exercised end to end.
+func ExampleCLIFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	path := filepath.Join("cmd", codegen.SnakeCase(design.Root.API.Name)+"cli", "main.go")
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "encoding/json"},
+		{Path: "flag"},
+		{Path: "fmt"},
+		{Path: "net/http"},
+		{Path: "net/url"},
+		{Path: "os"},
+		{Path: "strings"},
+		{Path: "time"},
+		{Path: "github.com/go-kit/kit/endpoint"},
+		{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+		{Path: "goa.design/goa/http", Name: "goahttp"},
+		{Path: filepath.Join(genpkg, "http", "cli")},
+	}
+	var svcdata []*httpcodegen.ServiceData
+	for _, svc := range root.HTTPServices {
+		data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		svcdata = append(svcdata, data)
+		specs = append(specs, &codegen.ImportSpec{
+			Path: filepath.Join(genpkg, "http", codegen.SnakeCase(svc.Name()), "kitclient"),
+			Name: data.Service.PkgName + "kc",
+		})
+	}
+	fm := codegen.TemplateFuncs()
+	fm["kebab"] = codegen.KebabCase
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", "main", specs),
+		{
+			Name:   "goakit-cli-main",
+			Source: cliMainT,
+			Data:   map[string]interface{}{"APIName": design.Root.API.Name},
+		},
+		{
+			Name:    "goakit-cli-endpoint",
+			Source:  cliEndpointT,
+			Data:    svcdata,
+			FuncMap: fm,
+		},
+	}
+
+	return []*codegen.File{{Path: path, SectionTemplates: sections}}
+}
+
+// input: map[string]interface{}{"APIName": string}
+const cliMainT = `func main() {
+	var (
+		addr    = flag.String("url", "http://localhost:8080", "` + "`" + `URL` + "`" + ` to service host")
+		verbose = flag.Bool("verbose", false, "Print request and response details")
+		v       = flag.Bool("v", false, "Print request and response details")
+		timeout = flag.Int("timeout", 30, "Maximum number of ` + "`" + `seconds` + "`" + ` to wait for response")
+	)
+	flag.Usage = usage
+	flag.Parse()
+
+	var (
+		scheme string
+		host   string
+		debug  bool
+	)
+	{
+		u, err := url.Parse(*addr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid URL %#v: %s", *addr, err)
+			os.Exit(1)
+		}
+		scheme = u.Scheme
+		host = u.Host
+		if scheme == "" {
+			scheme = "http"
+		}
+		debug = *verbose || *v
+	}
+
+	var (
+		doer goahttp.Doer
+	)
+	{
+		doer = &http.Client{Timeout: time.Duration(*timeout) * time.Second}
+		if debug {
+			doer = goahttp.NewDebugDoer(doer)
+		}
+	}
+
+	// Parse the method flags and build the payload with the goa generated
+	// cli package, the request is made with the go-kit HTTP client of the
+	// method.
+	_, payload, err := cli.ParseEndpoint(
+		scheme,
+		host,
+		doer,
+		goahttp.RequestEncoder,
+		goahttp.ResponseDecoder,
+		debug,
+	)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+		fmt.Fprintln(os.Stderr, "run '"+os.Args[0]+" --help' for detailed usage.")
+		os.Exit(1)
+	}
+	e, err := kitEndpoint(flag.Arg(0), flag.Arg(1), scheme, host, doer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	data, err := e(context.Background(), payload)
+
+	if debug {
+		doer.(goahttp.DebugDoer).Fprint(os.Stderr)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+
+	if data != nil && !debug {
+		m, _ := json.MarshalIndent(data, "", "    ")
+		fmt.Println(string(m))
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the {{ .APIName }} API.
+
+Usage:
+    %s [-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+
+    -url URL:    specify service URL (http://localhost:8080)
+    -timeout:    maximum number of seconds to wait for response (30)
+    -verbose|-v: print request and response details (false)
+
+Commands:
+%s
+Additional help:
+    %s SERVICE [ENDPOINT] --help
+
+Example:
+%s
+` + "`" + `, os.Args[0], os.Args[0], indent(cli.UsageCommands()), os.Args[0], indent(cli.UsageExamples()))
+}
+
+func indent(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "    " + strings.Replace(s, "\n", "\n    ", -1)
+}
+`
+
+// input: []ServiceData
+const cliEndpointT = `// kitEndpoint returns the go-kit HTTP client endpoint of the given service
+// method. The clients make the requests using the given doer.
+func kitEndpoint(svc, method, scheme, host string, doer goahttp.Doer) (endpoint.Endpoint, error) {
+	opts := []kithttp.ClientOption{kithttp.SetClient(doer)}
+	switch svc {
+{{- range . }}
+	case {{ printf "%q" (kebab .Service.Name) }}:
+		e := {{ .Service.PkgName }}kc.New(scheme, host, goahttp.RequestEncoder, goahttp.ResponseDecoder, opts...)
+		switch method {
+	{{- range .Endpoints }}
+		case {{ printf "%q" (kebab .Method.Name) }}:
+			return e.{{ .Method.VarName }}, nil
+	{{- end }}
+		}
+{{- end }}
+	}
+	return nil, fmt.Errorf("unknown %q endpoint %q", svc, method)
+}
+`
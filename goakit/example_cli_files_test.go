@@ -0,0 +1,36 @@
+package goakit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestExampleCLIFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code string
+	}{
+		"multi-endpoints": {testdata.MultiEndpointDSL, testdata.MultiEndpointCLIEndpointCode},
+		"streaming":       {testdata.StreamingDSL, testdata.StreamingCLIEndpointCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := ExampleCLIFiles("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			path := filepath.Join("cmd", codegen.SnakeCase(design.Root.API.Name)+"cli", "main.go")
+			if fs[0].Path != path {
+				t.Errorf("got path %q, expected %q", fs[0].Path, path)
+			}
+			testCode(t, fs[0], "goakit-cli-endpoint", []string{c.Code})
+		})
+	}
+}
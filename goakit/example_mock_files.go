@@ -0,0 +1,207 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+	kitdesign "goa.design/plugins/goakit/design"
+)
+
+// ExampleMockFiles returns the mock implementations of the services that
+// enable them with the Mock DSL. The mock methods return the examples defined
+// in the design or random values that honor the design validations, or the
+// error selected by name with the kitmock.ErrorHeader request header.
+func ExampleMockFiles(genpkg string) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range design.Root.Services {
+		if kitdesign.Root.Mock(svc) == nil {
+			continue
+		}
+		fw = append(fw, mockServiceFile(genpkg, svc))
+	}
+	return fw
+}
+
+// mockServiceFile returns the mock implementation of the given service.
+func mockServiceFile(genpkg string, svc *design.ServiceExpr) *codegen.File {
+	path := codegen.SnakeCase(svc.Name) + "_mock.go"
+	data := service.Services.Get(svc.Name)
+	r := design.Root.API.Random()
+	sections := []*codegen.SectionTemplate{
+		codegen.Header("", codegen.KebabCase(design.Root.API.Name), []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "github.com/go-kit/kit/log"},
+			{Path: "goa.design/plugins/goakit/kitmock"},
+			{Path: filepath.Join(genpkg, svc.Name), Name: data.PkgName},
+		}),
+		{Name: "goakit-mock-service-struct", Source: mockServiceStructT, Data: data},
+	}
+	for _, m := range data.Methods {
+		me := svc.Method(m.Name)
+		var payloadRef, resultRef, resultCode, streamRef string
+		if m.Payload != "" {
+			payloadRef = data.Scope.GoFullTypeRef(me.Payload, data.PkgName)
+		}
+		if m.ServerStream != nil {
+			// The mock streaming methods send no result on the stream.
+			streamRef = data.PkgName + "." + m.ServerStream.Interface
+		} else if m.Result != "" {
+			resultRef = data.Scope.GoFullTypeRef(me.Result, data.PkgName)
+			resultCode = mockValueCode(me.Result, me.Result.Example(r), data.Scope, data.PkgName)
+		}
+		var errs []map[string]string
+		for _, e := range me.Errors {
+			var code string
+			if e.Type == design.ErrorResult {
+				code = fmt.Sprintf("%s.Make%s(kitmock.NewError(%q))", data.PkgName, codegen.Goify(e.Name, true), e.Name)
+			} else {
+				code = mockValueCode(e.AttributeExpr, e.AttributeExpr.Example(r), data.Scope, data.PkgName)
+			}
+			errs = append(errs, map[string]string{"Name": e.Name, "Code": code})
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-mock-endpoint",
+			Source: mockEndpointImplT,
+			Data: map[string]interface{}{
+				"ServiceVarName": data.VarName,
+				"Method":         m,
+				"PayloadRef":     payloadRef,
+				"ResultRef":      resultRef,
+				"ResultCode":     resultCode,
+				"StreamRef":      streamRef,
+				"Errors":         errs,
+			},
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// mockValueCode returns the Go code of a literal initialized with the example
+// value v of the given attribute. The literal uses the types defined in the
+// service package pkg.
+func mockValueCode(att *design.AttributeExpr, v interface{}, scope *codegen.NameScope, pkg string) string {
+	switch dt := att.Type.(type) {
+	case design.UserType:
+		if design.AsObject(dt) != nil {
+			return fmt.Sprintf("&%s{%s}", scope.GoFullTypeName(att, pkg), mockFieldsCode(dt.Attribute(), v, scope, pkg))
+		}
+		return fmt.Sprintf("%s(%s)", scope.GoFullTypeName(att, pkg), mockValueCode(dt.Attribute(), v, scope, pkg))
+	case *design.Object:
+		// Inline objects are generated as anonymous struct pointers.
+		return fmt.Sprintf("&%s{%s}", scope.GoFullTypeName(att, pkg), mockFieldsCode(att, v, scope, pkg))
+	case *design.Array:
+		// The examples defined in the design may use any slice type.
+		var elems []string
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			for i := 0; i < rv.Len(); i++ {
+				elems = append(elems, mockValueCode(dt.ElemType, rv.Index(i).Interface(), scope, pkg))
+			}
+		}
+		return fmt.Sprintf("%s{%s}", scope.GoFullTypeRef(att, pkg), strings.Join(elems, ", "))
+	case *design.Map:
+		var elems []string
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Map {
+			for _, k := range rv.MapKeys() {
+				elems = append(elems, fmt.Sprintf("%s: %s",
+					mockValueCode(dt.KeyType, k.Interface(), scope, pkg),
+					mockValueCode(dt.ElemType, rv.MapIndex(k).Interface(), scope, pkg)))
+			}
+		}
+		sort.Strings(elems)
+		return fmt.Sprintf("%s{%s}", scope.GoFullTypeRef(att, pkg), strings.Join(elems, ", "))
+	case design.Primitive:
+		switch dt.Kind() {
+		case design.BooleanKind:
+			return fmt.Sprintf("%t", v)
+		case design.IntKind, design.Int32Kind, design.Int64Kind,
+			design.UIntKind, design.UInt32Kind, design.UInt64Kind:
+			return fmt.Sprintf("%d", v)
+		case design.Float32Kind, design.Float64Kind:
+			return fmt.Sprintf("%v", v)
+		case design.StringKind:
+			return fmt.Sprintf("%q", v)
+		case design.BytesKind:
+			return fmt.Sprintf("[]byte(%q)", v)
+		default:
+			return fmt.Sprintf("%#v", v)
+		}
+	default:
+		return "nil"
+	}
+}
+
+// mockFieldsCode returns the Go code of the fields of a struct literal
+// initialized with the example value v of the given object attribute.
+func mockFieldsCode(att *design.AttributeExpr, v interface{}, scope *codegen.NameScope, pkg string) string {
+	vals, _ := v.(map[string]interface{})
+	var fields []string
+	for _, nat := range *design.AsObject(att.Type) {
+		fv, ok := vals[nat.Name]
+		if !ok || fv == nil {
+			continue
+		}
+		code := mockValueCode(nat.Attribute, fv, scope, pkg)
+		if att.IsPrimitivePointer(nat.Name, true) {
+			code = fmt.Sprintf("kitmock.%s(%s)", mockPointerFuncs[nat.Attribute.Type.Kind()], code)
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", codegen.Goify(nat.Name, true), code))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// mockPointerFuncs lists the names of the kitmock functions that return
+// pointers to primitive values indexed by primitive kind.
+var mockPointerFuncs = map[design.Kind]string{
+	design.BooleanKind: "Bool",
+	design.IntKind:     "Int",
+	design.Int32Kind:   "Int32",
+	design.Int64Kind:   "Int64",
+	design.UIntKind:    "UInt",
+	design.UInt32Kind:  "UInt32",
+	design.UInt64Kind:  "UInt64",
+	design.Float32Kind: "Float32",
+	design.Float64Kind: "Float64",
+	design.StringKind:  "String",
+}
+
+// input: service.Data
+const mockServiceStructT = `{{ printf "%s service mock implementation.\nThe mock methods return the examples defined in the design or random values that honor the design validations. They return the method error whose name is given in the X-Mock-Error request header instead when the header is set." .Name | comment }}
+type {{ .VarName }}Mock struct {
+	logger log.Logger
+}
+
+{{ printf "New%sMock returns the %s service mock implementation." .StructName .Name | comment }}
+func New{{ .StructName }}Mock(logger log.Logger) {{ .PkgName }}.Service {
+	return &{{ .VarName }}Mock{logger}
+}
+`
+
+// input: map[string]interface{}{"ServiceVarName": string, "Method": service.MethodData, "PayloadRef": string, "ResultRef": string, "ResultCode": string, "StreamRef": string, "Errors": []map[string]string}
+const mockEndpointImplT = `{{ comment .Method.Description }}
+func (s *{{ .ServiceVarName }}Mock) {{ .Method.VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}{{ if .StreamRef }}, stream {{ .StreamRef }}{{ end }}) ({{ if .ResultRef }}{{ .ResultRef }}, {{ end }}error) {
+{{- if .ResultRef }}
+	var res {{ .ResultRef }}
+{{- end }}
+	s.logger.Log("msg", "{{ .ServiceVarName }}.{{ .Method.Name }}", "mock", true)
+	switch name := kitmock.Error(ctx); name {
+	case "":
+{{- if .ResultRef }}
+		res = {{ .ResultCode }}
+{{- end }}
+{{- range .Errors }}
+	case {{ printf "%q" .Name }}:
+		return {{ if $.ResultRef }}res, {{ end }}{{ .Code }}
+{{- end }}
+	default:
+		return {{ if .ResultRef }}res, {{ end }}kitmock.UnknownError(name)
+	}
+	return {{ if .ResultRef }}res, {{ end }}nil
+}
+`
@@ -0,0 +1,24 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestExampleMockFiles(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.MockDSL)
+	fs := ExampleMockFiles("")
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	if fs[0].Path != "mock_service_mock.go" {
+		t.Errorf("got path %q, expected %q", fs[0].Path, "mock_service_mock.go")
+	}
+	testCode(t, fs[0], "goakit-mock-service-struct", []string{testdata.MockServiceMockStructCode})
+	testCode(t, fs[0], "goakit-mock-endpoint", []string{
+		testdata.ResultMethodMockCode,
+		testdata.NoResultMethodMockCode,
+	})
+}
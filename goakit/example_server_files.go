@@ -6,48 +6,70 @@ import (
 	"strings"
 
 	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
 	"goa.design/goa/design"
+	grpcdesign "goa.design/goa/grpc/design"
 	httpcodegen "goa.design/goa/http/codegen"
 	httpdesign "goa.design/goa/http/design"
+	kitdesign "goa.design/plugins/goakit/design"
 )
 
 // ExampleServerFiles returns and example main and dummy service
-// implementations.
-func ExampleServerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
-	fw := make([]*codegen.File, len(root.HTTPServices)+1)
-	for i, svc := range root.HTTPServices {
+// implementations. The example main serves the HTTP transport if httpRoot is
+// not nil and the gRPC transport if grpcRoot is not nil.
+func ExampleServerFiles(genpkg string, httpRoot *httpdesign.RootExpr, grpcRoot *grpcdesign.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, len(design.Root.Services)+1)
+	for i, svc := range design.Root.Services {
 		fw[i] = dummyServiceFile(genpkg, svc)
 	}
-	fw[len(root.HTTPServices)] = exampleMain(genpkg, root)
+	fw[len(design.Root.Services)] = exampleMain(genpkg, httpRoot, grpcRoot)
 	return fw
 }
 
 // dummyServiceFile returns a dummy implementation of the given service.
-func dummyServiceFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
-	path := codegen.SnakeCase(svc.Name()) + ".go"
-	data := httpcodegen.HTTPServices.Get(svc.Name())
-	pkgName := httpcodegen.HTTPServices.Get(svc.Name()).Service.PkgName
+func dummyServiceFile(genpkg string, svc *design.ServiceExpr) *codegen.File {
+	path := codegen.SnakeCase(svc.Name) + ".go"
+	data := service.Services.Get(svc.Name)
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", codegen.KebabCase(design.Root.API.Name), []*codegen.ImportSpec{
 			{Path: "context"},
 			{Path: "github.com/go-kit/kit/log"},
-			{Path: filepath.Join(genpkg, svc.Name()), Name: pkgName},
+			{Path: filepath.Join(genpkg, svc.Name), Name: data.PkgName},
 		}),
 		{Name: "goakit-dummy-service-struct", Source: dummyServiceStructT, Data: data},
 	}
-	for _, e := range data.Endpoints {
+	for _, m := range data.Methods {
+		// The method data type references are relative to the service
+		// package, qualify them for use in the example package.
+		var payloadRef, resultRef, streamRef string
+		me := svc.Method(m.Name)
+		if m.Payload != "" {
+			payloadRef = data.Scope.GoFullTypeRef(me.Payload, data.PkgName)
+		}
+		if m.ServerStream != nil {
+			// The streaming methods send their results on the stream.
+			streamRef = data.PkgName + "." + m.ServerStream.Interface
+		} else if m.Result != "" {
+			resultRef = data.Scope.GoFullTypeRef(me.Result, data.PkgName)
+		}
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "goakit-dummy-endpoint",
 			Source: dummyEndpointImplT,
-			Data:   e,
+			Data: map[string]interface{}{
+				"ServiceVarName": data.VarName,
+				"Method":         m,
+				"PayloadRef":     payloadRef,
+				"ResultRef":      resultRef,
+				"StreamRef":      streamRef,
+			},
 		})
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
-func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
-	path := filepath.Join("cmd", codegen.SnakeCase(root.Design.API.Name)+"svc", "main.go")
+func exampleMain(genpkg string, httpRoot *httpdesign.RootExpr, grpcRoot *grpcdesign.RootExpr) *codegen.File {
+	path := filepath.Join("cmd", codegen.SnakeCase(design.Root.API.Name)+"svc", "main.go")
 	idx := strings.LastIndex(genpkg, string(os.PathSeparator))
 	rootPath := "."
 	if idx > 0 {
@@ -57,43 +79,115 @@ func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 		{Path: "context"},
 		{Path: "flag"},
 		{Path: "fmt"},
+		{Path: "net"},
 		{Path: "net/http"},
+		{Path: "net/http/pprof", Name: "_"},
 		{Path: "os"},
 		{Path: "os/signal"},
+		{Path: "syscall"},
 		{Path: "time"},
-		{Path: "github.com/go-kit/kit/endpoint"},
 		{Path: "github.com/go-kit/kit/log"},
 		{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+		{Path: "github.com/gorilla/websocket"},
+		{Path: "github.com/nats-io/nats.go"},
+		{Path: "github.com/oklog/run"},
+		{Path: "github.com/opentracing/opentracing-go", Name: "opentracing"},
+		{Path: "github.com/opentracing/opentracing-go/mocktracer"},
+		{Path: "github.com/prometheus/client_golang/prometheus/promhttp"},
 		{Path: "goa.design/goa", Name: "goa"},
 		{Path: "goa.design/goa/http", Name: "goahttp"},
-		{Path: rootPath, Name: codegen.KebabCase(root.Design.API.Name)},
+		{Path: rootPath, Name: codegen.KebabCase(design.Root.API.Name)},
 		{Path: "goa.design/goa/http/middleware"},
+		{Path: filepath.Join(genpkg, "kitmetrics")},
+		{Path: "goa.design/plugins/goakit/kitendpoint"},
+		{Path: "google.golang.org/grpc"},
 	}
-	for _, svc := range root.HTTPServices {
-		pkgName := httpcodegen.HTTPServices.Get(svc.Name()).Service.PkgName
-		specs = append(specs, &codegen.ImportSpec{
-			Path: filepath.Join(genpkg, "http", svc.Name(), "kitserver"),
-			Name: pkgName + "kitsvr",
-		})
-		specs = append(specs, &codegen.ImportSpec{
-			Path: filepath.Join(genpkg, svc.Name()),
-			Name: pkgName,
-		})
+	var (
+		svcdata  []*service.Data
+		httpdata []*httpcodegen.ServiceData
+		natsdata []*httpcodegen.ServiceData
+		grpcdata []*GRPCServiceData
+		mwdata   = make(map[string]bool)
+		strdata  = make(map[string]bool)
+		errdata  = make(map[string][]string)
+		mockdata = make(map[string]bool)
+	)
+	for _, svc := range design.Root.Services {
+		data := service.Services.Get(svc.Name)
+		svcdata = append(svcdata, data)
+		mwdata[data.Name] = hasMiddleware(data)
+		mockdata[data.Name] = kitdesign.Root.Mock(svc) != nil
 		specs = append(specs, &codegen.ImportSpec{
-			Path: filepath.Join(genpkg, "http", codegen.SnakeCase(svc.Name()), "server"),
-			Name: pkgName + "svr",
+			Path: filepath.Join(genpkg, svc.Name),
+			Name: data.PkgName,
 		})
 	}
+	if httpRoot != nil {
+		for _, svc := range httpRoot.HTTPServices {
+			pkgName := httpcodegen.HTTPServices.Get(svc.Name()).Service.PkgName
+			specs = append(specs, &codegen.ImportSpec{
+				Path: filepath.Join(genpkg, "http", svc.Name(), "kitserver"),
+				Name: pkgName + "kitsvr",
+			})
+			specs = append(specs, &codegen.ImportSpec{
+				Path: filepath.Join(genpkg, "http", codegen.SnakeCase(svc.Name()), "server"),
+				Name: pkgName + "svr",
+			})
+			specs = append(specs, &codegen.ImportSpec{
+				Path: filepath.Join(genpkg, "http", codegen.SnakeCase(svc.Name()), "kitjsonrpc"),
+				Name: pkgName + "kitrpc",
+			})
+			data := httpcodegen.HTTPServices.Get(svc.Name())
+			httpdata = append(httpdata, data)
+			kdata := kitServiceData(data)
+			if len(kdata.Endpoints) < len(data.Endpoints) {
+				strdata[svc.Name()] = true
+			}
+			for _, e := range kdata.Endpoints {
+				if len(e.Errors) > 0 {
+					errdata[svc.Name()] = append(errdata[svc.Name()], e.Method.VarName)
+				}
+			}
+			if kitdesign.Root.NATS(svc.ServiceExpr) != nil {
+				specs = append(specs, &codegen.ImportSpec{
+					Path: filepath.Join(genpkg, "nats", codegen.SnakeCase(svc.Name()), "kitnats"),
+					Name: pkgName + "kitnats",
+				})
+				natsdata = append(natsdata, httpcodegen.HTTPServices.Get(svc.Name()))
+			}
+		}
+	}
+	if grpcRoot != nil {
+		for _, svc := range grpcRoot.GRPCServices {
+			data := BuildGRPCServiceData(svc)
+			specs = append(specs, &codegen.ImportSpec{
+				Path: filepath.Join(genpkg, "grpc", codegen.SnakeCase(svc.Name()), "kitserver"),
+				Name: data.Service.PkgName + "kitgrpcsvr",
+			})
+			specs = append(specs, &codegen.ImportSpec{
+				Path: filepath.Join(genpkg, "grpc", codegen.SnakeCase(svc.Name()), "pb"),
+				Name: data.PBPkgName,
+			})
+			grpcdata = append(grpcdata, data)
+		}
+	}
+	if len(kitdesign.Root.MockServices) > 0 {
+		specs = append(specs, &codegen.ImportSpec{Path: "goa.design/plugins/goakit/kitmock"})
+	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", "main", specs),
 	}
-	var svcdata []*httpcodegen.ServiceData
-	for _, svc := range root.HTTPServices {
-		svcdata = append(svcdata, httpcodegen.HTTPServices.Get(svc.Name()))
-	}
 	data := map[string]interface{}{
-		"Services": svcdata,
-		"APIPkg":   codegen.KebabCase(root.Design.API.Name),
+		"APIServices":  svcdata,
+		"Services":     httpdata,
+		"GRPCServices": grpcdata,
+		"NATSServices": natsdata,
+		"Middleware":   mwdata,
+		"Streaming":    strdata,
+		"Errors":       errdata,
+		"Mocks":        mockdata,
+		"Mock":         len(kitdesign.Root.MockServices) > 0,
+		"APIPkg":       codegen.KebabCase(design.Root.API.Name),
 	}
 	sections = append(sections, &codegen.SectionTemplate{
 		Name:   "goakit-main",
@@ -104,80 +198,151 @@ func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
-// input: ServiceData
-const dummyServiceStructT = `{{ printf "%s service example implementation.\nThe example methods log the requests and return zero values." .Service.Name | comment }}
-type {{ .Service.VarName }}Svc struct {
+// input: service.Data
+const dummyServiceStructT = `{{ printf "%s service example implementation.\nThe example methods log the requests and return zero values." .Name | comment }}
+type {{ .VarName }}Svc struct {
 	logger log.Logger
 }
 
-{{ printf "New%s returns the %s service implementation." .Service.StructName .Service.Name | comment }}
-func New{{ .Service.StructName }}(logger log.Logger) {{ .Service.PkgName }}.Service {
-	return &{{ .Service.VarName }}Svc{logger}
+{{ printf "New%s returns the %s service implementation." .StructName .Name | comment }}
+func New{{ .StructName }}(logger log.Logger) {{ .PkgName }}.Service {
+	return &{{ .VarName }}Svc{logger}
 }
 `
 
-// input: EndpointData
+// input: map[string]interface{}{"ServiceVarName": string, "Method": service.MethodData, "PayloadRef": string, "ResultRef": string, "StreamRef": string}
 const dummyEndpointImplT = `{{ comment .Method.Description }}
-func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ if .Payload.Ref }}, p {{ .Payload.Ref }}{{ end }}) ({{ if .Result.Ref }}{{ .Result.Ref }}, {{ end }}error) {
-{{- if .Result.Ref }}
-	var res {{ .Result.Ref }}
+func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}{{ if .StreamRef }}, stream {{ .StreamRef }}{{ end }}) ({{ if .ResultRef }}{{ .ResultRef }}, {{ end }}error) {
+{{- if .ResultRef }}
+	var res {{ .ResultRef }}
 {{- end }}
 	s.logger.Log("msg", "{{ .ServiceVarName }}.{{ .Method.Name }}")
-	return {{ if .Result.Ref }}res, {{ end }}nil
+	return {{ if .ResultRef }}res, {{ end }}nil
 }
 `
 
-// input: map[string]interface{}{"Services":[]ServiceData, "APIPkg": string}
+// input: map[string]interface{}{"APIServices":[]service.Data, "Services":[]ServiceData, "GRPCServices":[]GRPCServiceData, "NATSServices":[]ServiceData, "Middleware": map[string]bool, "Streaming": map[string]bool, "Errors": map[string][]string, "Mocks": map[string]bool, "Mock": bool, "APIPkg": string}
 const mainT = `func main() {
 	// Define command line flags, add any other flag required to configure
 	// the service.
 	var (
+	{{- if .Services }}
 		addr = flag.String("listen", ":8080", "HTTP listen ` + "`" + `address` + "`" + `")
+	{{- end }}
+	{{- if .GRPCServices }}
+		grpcAddr = flag.String("grpc-listen", ":8081", "gRPC listen ` + "`" + `address` + "`" + `")
+	{{- end }}
+	{{- if .NATSServices }}
+		natsURL = flag.String("nats-url", "", "NATS server ` + "`" + `URL` + "`" + `, the services do not serve NATS requests if empty")
+	{{- end }}
+		debugAddr   = flag.String("debug-listen", ":8082", "debug and pprof HTTP listen ` + "`" + `address` + "`" + `")
+		metricsAddr = flag.String("metrics-listen", ":8083", "Prometheus metrics HTTP listen ` + "`" + `address` + "`" + `")
+		tracerName  = flag.String("tracer", "noop", "` + "`" + `tracer` + "`" + ` used to record the request spans (noop or memory)")
+	{{- if .Mock }}
+		mock        = flag.Bool("mock", false, "serve the examples defined in the design and the errors selected with the X-Mock-Error request header")
+	{{- end }}
 	)
 	flag.Parse()
 
-	// Setup logger.
+	// Setup logger{{ if .Services }} and goa log adapter. The go-kit loggers implement the
+	// goa middleware.Logger interface so that the adapter logs the HTTP
+	// requests with the go-kit logger{{ end }}.
 	var (
-		logger log.Logger
+		logger  log.Logger
+	{{- if .Services }}
+		adapter middleware.Logger
+	{{- end }}
 	)
 	{
 		logger = log.NewLogfmtLogger(os.Stderr)
 		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 		logger = log.With(logger, "caller", log.DefaultCaller)
+	{{- if .Services }}
+		adapter = log.With(logger, "transport", "HTTP")
+	{{- end }}
 	}
 
-	// Create the structs that implement the services.
+	// Setup tracer. The memory tracer records the spans in process, replace
+	// it with an OpenTracing tracer such as Jaeger or Zipkin to export the
+	// spans.
 	var (
-	{{- range .Services }}
-		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Svc {{.Service.PkgName}}.Service
+		tracer opentracing.Tracer
+	)
+	{
+		switch *tracerName {
+		case "noop":
+			tracer = opentracing.NoopTracer{}
+		case "memory":
+			tracer = mocktracer.New()
+		default:
+			logger.Log("error", fmt.Sprintf("invalid tracer %q", *tracerName))
+			os.Exit(1)
+		}
+	}
+
+	// Create the go-kit Prometheus metrics served by the metrics listener.
+	var (
+		metrics *kitmetrics.Metrics
+	)
+	{
+		metrics = kitmetrics.New()
+	}
+
+	// Create the structs that implement the services{{ if .Mock }}, the mock
+	// implementations return the examples defined in the design{{ end }}.
+	var (
+	{{- range .APIServices }}
+		{{-  if .Methods }}
+		{{ .VarName }}Svc {{.PkgName}}.Service
 		{{- end }}
 	{{- end }}
 	)
 	{
-	{{- range .Services }}
-		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Svc = {{ $.APIPkg }}.New{{ .Service.StructName }}(logger)
+	{{- range .APIServices }}
+		{{-  if .Methods }}
+			{{- if index $.Mocks .Name }}
+		if *mock {
+			{{ .VarName }}Svc = {{ $.APIPkg }}.New{{ .StructName }}Mock(logger)
+		} else {
+			{{ .VarName }}Svc = {{ $.APIPkg }}.New{{ .StructName }}(logger)
+		}
+			{{- else }}
+		{{ .VarName }}Svc = {{ $.APIPkg }}.New{{ .StructName }}(logger)
+			{{- end }}
+			{{- if index $.Middleware .Name }}
+		{{ .VarName }}Svc = {{ .PkgName }}.LoggingMiddleware(logger)({{ .VarName }}Svc)
+			{{- end }}
 		{{- end }}
 	{{- end }}
 	}
 
 	// Wrap the services in endpoints that can be invoked from other
 	// services potentially running in different processes.
+	{{- if .Errors }} The error
+	// middlewares are applied first so that the tracing and metrics
+	// middlewares observe the errors defined in the design.
+	{{- end }}
 	var (
-	{{- range .Services }}
-		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Endpoints *{{.Service.PkgName}}.Endpoints
+	{{- range .APIServices }}
+		{{-  if .Methods }}
+		{{ .VarName }}Endpoints *{{.PkgName}}.Endpoints
 		{{- end }}
 	{{- end }}
 	)
 	{
-	{{- range .Services }}
-		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Endpoints = {{ .Service.PkgName }}.NewEndpoints({{ .Service.VarName }}Svc)
+	{{- range .APIServices }}
+		{{-  if .Methods }}
+		{{ .VarName }}Endpoints = {{ .PkgName }}.NewEndpoints({{ .VarName }}Svc)
+			{{- $svc := . }}
+			{{- range index $.Errors .Name }}
+		{{ $svc.VarName }}Endpoints.{{ . }} = {{ $svc.PkgName }}kitsvr.{{ . }}ErrorMiddleware({{ $svc.VarName }}Endpoints.{{ . }})
+			{{- end }}
+		{{ .VarName }}Endpoints.Apply(kitendpoint.Tracing(tracer, {{ .PkgName }}.ServiceName))
+		{{ .VarName }}Endpoints.Apply(metrics.Middleware({{ .PkgName }}.ServiceName))
 		{{- end }}
 	{{- end }}
 	}
+{{- if .Services }}
 
 	// Provide the transport specific request decoder and response encoder.
 	// The goa http package has built-in support for JSON, XML and gob.
@@ -187,6 +352,13 @@ const mainT = `func main() {
 		dec = goahttp.RequestDecoder
 		enc = goahttp.ResponseEncoder
 	)
+	{{- if .Streaming }}
+
+	// Upgrade the requests made to the streaming endpoints to WebSocket
+	// connections, go-kit cannot serve these endpoints so the goa generated
+	// handlers serve them.
+	var upgrader goahttp.Upgrader = &websocket.Upgrader{}
+	{{- end }}
 
 	// Build the service HTTP request router (a.k.a. mux).
 	var mux goahttp.Muxer
@@ -198,27 +370,23 @@ const mainT = `func main() {
 	var (
 	{{- range .Services }}
 		{{- range .Endpoints }}
+			{{- if not .ServerStream }}
 		{{ .ServiceVarName }}{{ .Method.VarName }}Handler *kithttp.Server
+			{{- end }}
 		{{- end }}
 		{{ .Service.VarName }}Server *{{.Service.PkgName}}svr.Server
 	{{- end }}
 	)
 	{
-	{{- range .Services }}
 		eh := ErrorHandler(logger)
+	{{- range .Services }}
 		{{- range .Endpoints }}
-		{{ .ServiceVarName }}{{ .Method.VarName }}Handler = kithttp.NewServer(
-			endpoint.Endpoint({{ .ServiceVarName }}Endpoints.{{ .Method.VarName }}),
-			{{- if .Payload.Ref }}
-			{{ .ServicePkgName}}kitsvr.{{ .RequestDecoder }}(mux, dec),
-			{{- else }}
-			func(context.Context, *http.Request) (request interface{}, err error) { return nil, nil },
+			{{- if not .ServerStream }}
+		{{ .ServiceVarName }}{{ .Method.VarName }}Handler = {{ .ServicePkgName }}kitsvr.New{{ .Method.VarName }}Server({{ .ServiceVarName }}Endpoints.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ else }}dec{{ end }}, enc, {{ .ServicePkgName }}kitsvr.{{ .Method.VarName }}TraceServerBefore(tracer, logger))
 			{{- end }}
-			{{ .ServicePkgName}}kitsvr.{{ .ResponseEncoder }}(enc),
-		)
 		{{- end }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh)
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if index $.Streaming .Service.Name }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }})
 		{{-  else }}
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh)
 		{{-  end }}
@@ -228,61 +396,220 @@ const mainT = `func main() {
 	// Configure the mux.
 	{{- range .Services }}{{ $service := . }}
 		{{- range .Endpoints }}
+			{{- if .ServerStream }}
+	{{ .ServicePkgName}}kitsvr.{{ .MountHandler }}(mux, {{ $service.Service.VarName }}Server.{{ .Method.VarName }})
+			{{- else }}
 	{{ .ServicePkgName}}kitsvr.{{ .MountHandler }}(mux, {{ .ServiceVarName }}{{ .Method.VarName }}Handler)
+			{{- end }}
 		{{- end }}
 		{{- range .FileServers }}
 	{{ $service.Service.PkgName}}kitsvr.{{ .MountHandler }}(mux)
 		{{- end }}
 	{{- end }}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
+	// Serve the JSON-RPC requests made to the services.
+	{{- range .Services }}
+		{{- if .Endpoints }}
+	{{ .Service.PkgName }}kitrpc.Mount(mux, {{ .Service.PkgName }}kitrpc.NewServer({{ .Service.VarName }}Endpoints))
+		{{- end }}
+	{{- end }}
+{{- end }}
+{{- if .GRPCServices }}
+
+	// Wrap the endpoints with the go-kit gRPC transport layer and register
+	// them with the gRPC server.
+	var grpcsrv *grpc.Server
+	{
+		grpcsrv = grpc.NewServer()
+	{{- range .GRPCServices }}
+		{{ .PBPkgName }}.Register{{ .ServerInterface }}(grpcsrv, {{ .Service.PkgName }}kitgrpcsvr.NewServer({{ .Service.VarName }}Endpoints))
+	{{- end }}
+	}
+{{- end }}
+{{- if .NATSServices }}
+
+	// Subscribe the services to their NATS subjects when a NATS server URL
+	// is provided.
+	var nc *nats.Conn
+	if *natsURL != "" {
+		var err error
+		if nc, err = nats.Connect(*natsURL); err != nil {
+			logger.Log("error", err)
+			os.Exit(1)
+		}
+	{{- range .NATSServices }}
+		if _, err := {{ .Service.PkgName }}kitnats.Subscribe(nc, {{ .Service.VarName }}Endpoints); err != nil {
+			logger.Log("error", err)
+			os.Exit(1)
+		}
+		logger.Log("info", fmt.Sprintf("service %s subscribed to NATS queue %s", {{ .Service.PkgName }}.ServiceName, {{ .Service.PkgName }}kitnats.Queue))
+	{{- end }}
+	}
+{{- end }}
 
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the service to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
+	// Run the servers and the signal handler as the actors of a group, the
+	// first actor to return interrupts the others so that the process stops
+	// gracefully.
+	var g run.Group
+{{- if .Services }}
+	{
+		// Wrap the multiplexer with additional middlewares. Middlewares
+		// mounted here apply to all the service endpoints. The request ID
+		// middleware must wrap the others so that the request logs and the
+		// error handler can read the request ID.
+		var handler http.Handler = mux
+		{
+		{{- if .Mock }}
+			// Let the mock implementations return the errors selected
+			// with the X-Mock-Error request header.
+			if *mock {
+				handler = kitmock.Handler(handler)
+			}
+		{{- end }}
+			handler = middleware.Log(adapter)(handler)
+			handler = middleware.RequestID()(handler)
+		}
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: *addr, Handler: mux}
-	go func() {
+		// Start HTTP server using default configuration, change the code
+		// to configure the server as required by your service. The listen
+		// error is returned by the actor so that the group stops the
+		// other actors.
+		httpListener, err := net.Listen("tcp", *addr)
+		srv := &http.Server{Handler: handler}
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "HTTP", "during", "Listen", "error", err)
+				return err
+			}
 		{{- range .Services }}
-		for _, m := range {{ .Service.VarName }}Server.Mounts {
+			for _, m := range {{ .Service.VarName }}Server.Mounts {
 			{{- if .FileServers }}
-			logger.Log("info", fmt.Sprintf("service %s file %s mounted on %s %s", {{ .Service.VarName }}Server.Service(), m.Method, m.Verb, m.Pattern))
+				logger.Log("info", fmt.Sprintf("service %s file %s mounted on %s %s", {{ .Service.VarName }}Server.Service(), m.Method, m.Verb, m.Pattern))
 			{{- else }}
-			logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", {{ .Service.VarName }}Server.Service(), m.Method, m.Verb, m.Pattern))
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", {{ .Service.VarName }}Server.Service(), m.Method, m.Verb, m.Pattern))
+			{{- end }}
+			}
+			{{- if .Endpoints }}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", {{ .Service.VarName }}Server.Service(), {{ .Service.PkgName }}kitrpc.Path))
 			{{- end }}
-		}
 		{{- end }}
-		logger.Log("listening", *addr)
-		errc <- srv.ListenAndServe()
-	}()
+			logger.Log("transport", "HTTP", "addr", *addr)
+			return srv.Serve(httpListener)
+		}, func(error) {
+			// Shutdown gracefully with a 30s timeout.
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Log("transport", "HTTP", "during", "Shutdown", "error", err)
+			}
+		})
+	}
+{{- end }}
+{{- if .GRPCServices }}
+	{
+		// Start gRPC server using default configuration, change the code
+		// to configure the server as required by your service.
+		grpcListener, err := net.Listen("tcp", *grpcAddr)
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "gRPC", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "gRPC", "addr", *grpcAddr)
+			return grpcsrv.Serve(grpcListener)
+		}, func(error) {
+			grpcsrv.GracefulStop()
+		})
+	}
+{{- end }}
+	{
+		// Serve the pprof handlers registered on the default mux on a
+		// separate listener so that they are not exposed publicly.
+		debugListener, err := net.Listen("tcp", *debugAddr)
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "debug/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "debug/HTTP", "addr", *debugAddr)
+			return http.Serve(debugListener, http.DefaultServeMux)
+		}, func(error) {
+			if debugListener != nil {
+				debugListener.Close()
+			}
+		})
+	}
+	{
+		// Serve the Prometheus metrics on a separate listener, the
+		// metrics include the request count, duration and errors of the
+		// service endpoints.
+		metricsListener, err := net.Listen("tcp", *metricsAddr)
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "metrics/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "metrics/HTTP", "addr", *metricsAddr)
+			return http.Serve(metricsListener, metricsMux)
+		}, func(error) {
+			if metricsListener != nil {
+				metricsListener.Close()
+			}
+		})
+	}
+	{
+		// Stop the servers gracefully when the process receives SIGINT or
+		// SIGTERM.
+		cancelInterrupt := make(chan struct{})
+		g.Add(func() error {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+			select {
+			case sig := <-c:
+				return fmt.Errorf("received signal %s", sig)
+			case <-cancelInterrupt:
+				return nil
+			}
+		}, func(error) {
+			close(cancelInterrupt)
+		})
+	}
+
+	// Run the group until one of the actors returns.
+	logger.Log("exiting", g.Run())
+{{- if .NATSServices }}
 
-	// Wait for signal.
-	logger.Log("exiting", <-errc)
+	// Drain the NATS subscriptions so that the pending requests are served.
+	if nc != nil {
+		nc.Drain()
+	}
+{{- end }}
 
-	// Shutdown gracefully with a 30s timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
+	// Log the spans recorded by the memory tracer.
+	if mt, ok := tracer.(*mocktracer.MockTracer); ok {
+		for _, span := range mt.FinishedSpans() {
+			logger.Log("span", span.OperationName, "took", span.FinishTime.Sub(span.StartTime))
+		}
+	}
 
 	logger.Log("server", "exited")
 }
+{{- if .Services }}
 
 // ErrorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
-// to correlate.
+// to correlate. The ID is "-" if the request ID middleware did not run.
 func ErrorHandler(logger log.Logger) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
-		id := ctx.Value(middleware.RequestIDKey).(string)
+		id, ok := ctx.Value(middleware.RequestIDKey).(string)
+		if !ok {
+			id = "-"
+		}
 		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
 		logger.Log("error", fmt.Sprintf("[%s] ERROR: %s", id, err.Error()))
 	}
 }
+{{- end }}
 `
@@ -22,7 +22,7 @@ func TestExampleServerFiles(t *testing.T) {
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
 			httpcodegen.RunHTTPDSL(t, c.DSL)
-			fs := ExampleServerFiles("", httpdesign.Root)
+			fs := ExampleServerFiles("", httpdesign.Root, nil)
 			for _, f := range fs {
 				if filepath.Base(f.Path) == "main.go" {
 					continue
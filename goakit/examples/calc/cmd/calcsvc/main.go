@@ -4,38 +4,80 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/oklog/run"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	goahttp "goa.design/goa/http"
 	"goa.design/goa/http/middleware"
 	calc "goa.design/plugins/goakit/examples/calc"
 	calcsvc "goa.design/plugins/goakit/examples/calc/gen/calc"
+	calcsvckitrpc "goa.design/plugins/goakit/examples/calc/gen/http/calc/kitjsonrpc"
 	calcsvckitsvr "goa.design/plugins/goakit/examples/calc/gen/http/calc/kitserver"
 	calcsvcsvr "goa.design/plugins/goakit/examples/calc/gen/http/calc/server"
+	"goa.design/plugins/goakit/examples/calc/gen/kitmetrics"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 func main() {
 	// Define command line flags, add any other flag required to configure
 	// the service.
 	var (
-		addr = flag.String("listen", ":8080", "HTTP listen `address`")
+		addr        = flag.String("listen", ":8080", "HTTP listen `address`")
+		debugAddr   = flag.String("debug-listen", ":8082", "debug and pprof HTTP listen `address`")
+		metricsAddr = flag.String("metrics-listen", ":8083", "Prometheus metrics HTTP listen `address`")
+		tracerName  = flag.String("tracer", "noop", "`tracer` used to record the request spans (noop or memory)")
 	)
 	flag.Parse()
 
-	// Setup logger.
+	// Setup logger and goa log adapter. The go-kit loggers implement the
+	// goa middleware.Logger interface so that the adapter logs the HTTP
+	// requests with the go-kit logger.
 	var (
-		logger log.Logger
+		logger  log.Logger
+		adapter middleware.Logger
 	)
 	{
 		logger = log.NewLogfmtLogger(os.Stderr)
 		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 		logger = log.With(logger, "caller", log.DefaultCaller)
+		adapter = log.With(logger, "transport", "HTTP")
+	}
+
+	// Setup tracer. The memory tracer records the spans in process, replace
+	// it with an OpenTracing tracer such as Jaeger or Zipkin to export the
+	// spans.
+	var (
+		tracer opentracing.Tracer
+	)
+	{
+		switch *tracerName {
+		case "noop":
+			tracer = opentracing.NoopTracer{}
+		case "memory":
+			tracer = mocktracer.New()
+		default:
+			logger.Log("error", fmt.Sprintf("invalid tracer %q", *tracerName))
+			os.Exit(1)
+		}
+	}
+
+	// Create the go-kit Prometheus metrics served by the metrics listener.
+	var (
+		metrics *kitmetrics.Metrics
+	)
+	{
+		metrics = kitmetrics.New()
 	}
 
 	// Create the structs that implement the services.
@@ -44,6 +86,7 @@ func main() {
 	)
 	{
 		calcSvc = calc.NewCalc(logger)
+		calcSvc = calcsvc.LoggingMiddleware(logger)(calcSvc)
 	}
 
 	// Wrap the services in endpoints that can be invoked from other
@@ -53,6 +96,8 @@ func main() {
 	)
 	{
 		calcEndpoints = calcsvc.NewEndpoints(calcSvc)
+		calcEndpoints.Apply(kitendpoint.Tracing(tracer, calcsvc.ServiceName))
+		calcEndpoints.Apply(metrics.Middleware(calcsvc.ServiceName))
 	}
 
 	// Provide the transport specific request decoder and response encoder.
@@ -77,57 +122,134 @@ func main() {
 	)
 	{
 		eh := ErrorHandler(logger)
-		calcAddHandler = kithttp.NewServer(
-			endpoint.Endpoint(calcEndpoints.Add),
-			calcsvckitsvr.DecodeAddRequest(mux, dec),
-			calcsvckitsvr.EncodeAddResponse(enc),
-		)
+		calcAddHandler = calcsvckitsvr.NewAddServer(calcEndpoints.Add, mux, dec, enc, calcsvckitsvr.AddTraceServerBefore(tracer, logger))
 		calcServer = calcsvcsvr.New(calcEndpoints, mux, dec, enc, eh)
 	}
 
 	// Configure the mux.
 	calcsvckitsvr.MountAddHandler(mux, calcAddHandler)
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the service to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: *addr, Handler: mux}
-	go func() {
-		for _, m := range calcServer.Mounts {
-			logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", calcServer.Service(), m.Method, m.Verb, m.Pattern))
+	// Serve the JSON-RPC requests made to the services.
+	calcsvckitrpc.Mount(mux, calcsvckitrpc.NewServer(calcEndpoints))
+
+	// Run the servers and the signal handler as the actors of a group, the
+	// first actor to return interrupts the others so that the process stops
+	// gracefully.
+	var g run.Group
+	{
+		// Wrap the multiplexer with additional middlewares. Middlewares
+		// mounted here apply to all the service endpoints. The request ID
+		// middleware must wrap the others so that the request logs and the
+		// error handler can read the request ID.
+		var handler http.Handler = mux
+		{
+			handler = middleware.Log(adapter)(handler)
+			handler = middleware.RequestID()(handler)
 		}
-		logger.Log("listening", *addr)
-		errc <- srv.ListenAndServe()
-	}()
 
-	// Wait for signal.
-	logger.Log("exiting", <-errc)
+		// Start HTTP server using default configuration, change the code
+		// to configure the server as required by your service. The listen
+		// error is returned by the actor so that the group stops the
+		// other actors.
+		httpListener, err := net.Listen("tcp", *addr)
+		srv := &http.Server{Handler: handler}
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			for _, m := range calcServer.Mounts {
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", calcServer.Service(), m.Method, m.Verb, m.Pattern))
+			}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", calcServer.Service(), calcsvckitrpc.Path))
+			logger.Log("transport", "HTTP", "addr", *addr)
+			return srv.Serve(httpListener)
+		}, func(error) {
+			// Shutdown gracefully with a 30s timeout.
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Log("transport", "HTTP", "during", "Shutdown", "error", err)
+			}
+		})
+	}
+	{
+		// Serve the pprof handlers registered on the default mux on a
+		// separate listener so that they are not exposed publicly.
+		debugListener, err := net.Listen("tcp", *debugAddr)
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "debug/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "debug/HTTP", "addr", *debugAddr)
+			return http.Serve(debugListener, http.DefaultServeMux)
+		}, func(error) {
+			if debugListener != nil {
+				debugListener.Close()
+			}
+		})
+	}
+	{
+		// Serve the Prometheus metrics on a separate listener, the
+		// metrics include the request count, duration and errors of the
+		// service endpoints.
+		metricsListener, err := net.Listen("tcp", *metricsAddr)
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "metrics/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "metrics/HTTP", "addr", *metricsAddr)
+			return http.Serve(metricsListener, metricsMux)
+		}, func(error) {
+			if metricsListener != nil {
+				metricsListener.Close()
+			}
+		})
+	}
+	{
+		// Stop the servers gracefully when the process receives SIGINT or
+		// SIGTERM.
+		cancelInterrupt := make(chan struct{})
+		g.Add(func() error {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+			select {
+			case sig := <-c:
+				return fmt.Errorf("received signal %s", sig)
+			case <-cancelInterrupt:
+				return nil
+			}
+		}, func(error) {
+			close(cancelInterrupt)
+		})
+	}
+
+	// Run the group until one of the actors returns.
+	logger.Log("exiting", g.Run())
 
-	// Shutdown gracefully with a 30s timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
+	// Log the spans recorded by the memory tracer.
+	if mt, ok := tracer.(*mocktracer.MockTracer); ok {
+		for _, span := range mt.FinishedSpans() {
+			logger.Log("span", span.OperationName, "took", span.FinishTime.Sub(span.StartTime))
+		}
+	}
 
 	logger.Log("server", "exited")
 }
 
 // ErrorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
-// to correlate.
+// to correlate. The ID is "-" if the request ID middleware did not run.
 func ErrorHandler(logger log.Logger) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
-		id := ctx.Value(middleware.RequestIDKey).(string)
+		id, ok := ctx.Value(middleware.RequestIDKey).(string)
+		if !ok {
+			id = "-"
+		}
 		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
 		logger.Log("error", fmt.Sprintf("[%s] ERROR: %s", id, err.Error()))
 	}
@@ -11,6 +11,7 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 // Endpoints wraps the "calc" service endpoints.
@@ -30,6 +31,23 @@ func (e *Endpoints) Use(m func(endpoint.Endpoint) endpoint.Endpoint) {
 	e.Add = m(e.Add)
 }
 
+// Apply applies the middlewares returned by m to the "calc" service endpoints.
+// m is called with the name of the method implemented by each endpoint as
+// defined in the design. The endpoints for which m returns nil are left
+// unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("add"); mw != nil {
+		e.Add = mw(e.Add)
+	}
+}
+
+// UseGoa applies the goa endpoint middleware m to the "calc" service endpoints
+// so that the middlewares written for the services generated without goakit
+// may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+
 // NewAddEndpoint returns an endpoint function that calls the method "add" of
 // service "calc".
 func NewAddEndpoint(s Service) endpoint.Endpoint {
@@ -0,0 +1,52 @@
+package archiver
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/log"
+	archiversvc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/archiver"
+	"goa.design/plugins/goakit/kitmock"
+)
+
+// archiver service mock implementation.
+// The mock methods return the examples defined in the design or random values
+// that honor the design validations. They return the method error whose name
+// is given in the X-Mock-Error request header instead when the header is set.
+type archiverMock struct {
+	logger log.Logger
+}
+
+// NewArchiverMock returns the archiver service mock implementation.
+func NewArchiverMock(logger log.Logger) archiversvc.Service {
+	return &archiverMock{logger}
+}
+
+// Archive HTTP response
+func (s *archiverMock) Archive(ctx context.Context, p *archiversvc.ArchivePayload) (*archiversvc.ArchiveMedia, error) {
+	var res *archiversvc.ArchiveMedia
+	s.logger.Log("msg", "archiver.archive", "mock", true)
+	switch name := kitmock.Error(ctx); name {
+	case "":
+		res = &archiversvc.ArchiveMedia{Href: "/archive/1", Status: 200, Body: "Alias ipsa eum laborum ut quod."}
+	default:
+		return res, kitmock.UnknownError(name)
+	}
+	return res, nil
+}
+
+// Read HTTP response from archive
+func (s *archiverMock) Read(ctx context.Context, p *archiversvc.ReadPayload) (*archiversvc.ArchiveMedia, error) {
+	var res *archiversvc.ArchiveMedia
+	s.logger.Log("msg", "archiver.read", "mock", true)
+	switch name := kitmock.Error(ctx); name {
+	case "":
+		res = &archiversvc.ArchiveMedia{Href: "/archive/1", Status: 200, Body: "Ad beatae incidunt consequuntur aperiam aliquid."}
+	case "not_found":
+		return res, archiversvc.MakeNotFound(kitmock.NewError("not_found"))
+	case "bad_request":
+		return res, archiversvc.MakeBadRequest(kitmock.NewError("bad_request"))
+	default:
+		return res, kitmock.UnknownError(name)
+	}
+	return res, nil
+}
@@ -11,8 +11,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
 	goahttp "goa.design/goa/http"
+	archiversvckc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/kitclient"
 	"goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/cli"
+	healthkc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/health/kitclient"
 )
 
 func main() {
@@ -54,7 +58,10 @@ func main() {
 		}
 	}
 
-	endpoint, payload, err := cli.ParseEndpoint(
+	// Parse the method flags and build the payload with the goa generated
+	// cli package, the request is made with the go-kit HTTP client of the
+	// method.
+	_, payload, err := cli.ParseEndpoint(
 		scheme,
 		host,
 		doer,
@@ -70,8 +77,13 @@ func main() {
 		fmt.Fprintln(os.Stderr, "run '"+os.Args[0]+" --help' for detailed usage.")
 		os.Exit(1)
 	}
+	e, err := kitEndpoint(flag.Arg(0), flag.Arg(1), scheme, host, doer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
 
-	data, err := endpoint(context.Background(), payload)
+	data, err := e(context.Background(), payload)
 
 	if debug {
 		doer.(goahttp.DebugDoer).Fprint(os.Stderr)
@@ -114,3 +126,26 @@ func indent(s string) string {
 	}
 	return "    " + strings.Replace(s, "\n", "\n    ", -1)
 }
+
+// kitEndpoint returns the go-kit HTTP client endpoint of the given service
+// method. The clients make the requests using the given doer.
+func kitEndpoint(svc, method, scheme, host string, doer goahttp.Doer) (endpoint.Endpoint, error) {
+	opts := []kithttp.ClientOption{kithttp.SetClient(doer)}
+	switch svc {
+	case "archiver":
+		e := archiversvckc.New(scheme, host, goahttp.RequestEncoder, goahttp.ResponseDecoder, opts...)
+		switch method {
+		case "archive":
+			return e.Archive, nil
+		case "read":
+			return e.Read, nil
+		}
+	case "health":
+		e := healthkc.New(scheme, host, goahttp.RequestEncoder, goahttp.ResponseDecoder, opts...)
+		switch method {
+		case "show":
+			return e.Show, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %q endpoint %q", svc, method)
+}
@@ -4,62 +4,121 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 
-	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/oklog/run"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	goahttp "goa.design/goa/http"
 	"goa.design/goa/http/middleware"
 	archiver "goa.design/plugins/goakit/examples/fetcher/archiver"
 	archiversvc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/archiver"
 	health "goa.design/plugins/goakit/examples/fetcher/archiver/gen/health"
+	archiversvckitrpc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/kitjsonrpc"
 	archiversvckitsvr "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/kitserver"
 	archiversvcsvr "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/server"
+	healthkitrpc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/health/kitjsonrpc"
 	healthkitsvr "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/health/kitserver"
 	healthsvr "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/health/server"
+	"goa.design/plugins/goakit/examples/fetcher/archiver/gen/kitmetrics"
+	"goa.design/plugins/goakit/kitendpoint"
+	"goa.design/plugins/goakit/kitmock"
 )
 
 func main() {
 	// Define command line flags, add any other flag required to configure
 	// the service.
 	var (
-		addr = flag.String("listen", ":8081", "HTTP listen `address`")
+		addr        = flag.String("listen", ":8081", "HTTP listen `address`")
+		debugAddr   = flag.String("debug-listen", ":8084", "debug and pprof HTTP listen `address`")
+		metricsAddr = flag.String("metrics-listen", ":8085", "Prometheus metrics HTTP listen `address`")
+		tracerName  = flag.String("tracer", "noop", "`tracer` used to record the request spans (noop or memory)")
+		mock        = flag.Bool("mock", false, "serve the examples defined in the design and the errors selected with the X-Mock-Error request header")
 	)
 	flag.Parse()
 
-	// Setup logger.
+	// Setup logger and goa log adapter. The go-kit loggers implement the
+	// goa middleware.Logger interface so that the adapter logs the HTTP
+	// requests with the go-kit logger.
 	var (
-		logger log.Logger
+		logger  log.Logger
+		adapter middleware.Logger
 	)
 	{
 		logger = log.NewLogfmtLogger(os.Stderr)
 		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 		logger = log.With(logger, "caller", log.DefaultCaller)
+		adapter = log.With(logger, "transport", "HTTP")
 	}
 
-	// Create the structs that implement the services.
+	// Setup tracer. The memory tracer records the spans in process, replace
+	// it with an OpenTracing tracer such as Jaeger or Zipkin to export the
+	// spans.
 	var (
-		archiversvcs archiversvc.Service
-		healths      health.Service
+		tracer opentracing.Tracer
 	)
 	{
-		archiversvcs = archiver.NewArchiver(logger)
-		healths = archiver.NewHealth(logger)
+		switch *tracerName {
+		case "noop":
+			tracer = opentracing.NoopTracer{}
+		case "memory":
+			tracer = mocktracer.New()
+		default:
+			logger.Log("error", fmt.Sprintf("invalid tracer %q", *tracerName))
+			os.Exit(1)
+		}
+	}
+
+	// Create the go-kit Prometheus metrics served by the metrics listener.
+	var (
+		metrics *kitmetrics.Metrics
+	)
+	{
+		metrics = kitmetrics.New()
+	}
+
+	// Create the structs that implement the services, the mock
+	// implementations return the examples defined in the design.
+	var (
+		archiverSvc archiversvc.Service
+		healthSvc   health.Service
+	)
+	{
+		if *mock {
+			archiverSvc = archiver.NewArchiverMock(logger)
+		} else {
+			archiverSvc = archiver.NewArchiver(logger)
+		}
+		archiverSvc = archiversvc.LoggingMiddleware(logger)(archiverSvc)
+		healthSvc = archiver.NewHealth(logger)
+		healthSvc = health.LoggingMiddleware(logger)(healthSvc)
 	}
 
 	// Wrap the services in endpoints that can be invoked from other
-	// services potentially running in different processes.
+	// services potentially running in different processes. The error
+	// middlewares are applied first so that the tracing and metrics
+	// middlewares observe the errors defined in the design.
 	var (
-		archiversvce *archiversvc.Endpoints
-		healthe      *health.Endpoints
+		archiverEndpoints *archiversvc.Endpoints
+		healthEndpoints   *health.Endpoints
 	)
 	{
-		archiversvce = archiversvc.NewEndpoints(archiversvcs)
-		healthe = health.NewEndpoints(healths)
+		archiverEndpoints = archiversvc.NewEndpoints(archiverSvc)
+		archiverEndpoints.Read = archiversvckitsvr.ReadErrorMiddleware(archiverEndpoints.Read)
+		archiverEndpoints.Apply(kitendpoint.Tracing(tracer, archiversvc.ServiceName))
+		archiverEndpoints.Apply(metrics.Middleware(archiversvc.ServiceName))
+		healthEndpoints = health.NewEndpoints(healthSvc)
+		healthEndpoints.Apply(kitendpoint.Tracing(tracer, health.ServiceName))
+		healthEndpoints.Apply(metrics.Middleware(health.ServiceName))
 	}
 
 	// Provide the transport specific request decoder and response encoder.
@@ -79,81 +138,157 @@ func main() {
 
 	// Wrap the endpoints with the transport specific layer.
 	var (
-		archiversvcArchiveHandler *kithttp.Server
-		archiversvcReadHandler    *kithttp.Server
-		archiversvcServer         *archiversvcsvr.Server
-		healthShowHandler         *kithttp.Server
-		healthServer              *healthsvr.Server
+		archiverArchiveHandler *kithttp.Server
+		archiverReadHandler    *kithttp.Server
+		archiverServer         *archiversvcsvr.Server
+		healthShowHandler      *kithttp.Server
+		healthServer           *healthsvr.Server
 	)
 	{
 		eh := ErrorHandler(logger)
-		archiversvcArchiveHandler = kithttp.NewServer(
-			endpoint.Endpoint(archiversvce.Archive),
-			archiversvckitsvr.DecodeArchiveRequest(mux, dec),
-			archiversvckitsvr.EncodeArchiveResponse(enc),
-		)
-		archiversvcReadHandler = kithttp.NewServer(
-			endpoint.Endpoint(archiversvce.Read),
-			archiversvckitsvr.DecodeReadRequest(mux, dec),
-			archiversvckitsvr.EncodeReadResponse(enc),
-		)
-		archiversvcServer = archiversvcsvr.New(archiversvce, mux, dec, enc, eh)
-		healthShowHandler = kithttp.NewServer(
-			endpoint.Endpoint(healthe.Show),
-			func(context.Context, *http.Request) (request interface{}, err error) { return nil, nil },
-			healthkitsvr.EncodeShowResponse(enc),
-		)
-		healthServer = healthsvr.New(healthe, mux, dec, enc, eh)
+		archiverArchiveHandler = archiversvckitsvr.NewArchiveServer(archiverEndpoints.Archive, mux, dec, enc, archiversvckitsvr.ArchiveTraceServerBefore(tracer, logger))
+		archiverReadHandler = archiversvckitsvr.NewReadServer(archiverEndpoints.Read, mux, dec, enc, archiversvckitsvr.ReadTraceServerBefore(tracer, logger))
+		archiverServer = archiversvcsvr.New(archiverEndpoints, mux, dec, enc, eh)
+		healthShowHandler = healthkitsvr.NewShowServer(healthEndpoints.Show, mux, dec, enc, healthkitsvr.ShowTraceServerBefore(tracer, logger))
+		healthServer = healthsvr.New(healthEndpoints, mux, dec, enc, eh)
 	}
 
 	// Configure the mux.
-	archiversvckitsvr.MountArchiveHandler(mux, archiversvcArchiveHandler)
-	archiversvckitsvr.MountReadHandler(mux, archiversvcReadHandler)
+	archiversvckitsvr.MountArchiveHandler(mux, archiverArchiveHandler)
+	archiversvckitsvr.MountReadHandler(mux, archiverReadHandler)
 	healthkitsvr.MountShowHandler(mux, healthShowHandler)
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the service to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: *addr, Handler: mux}
-	go func() {
-		for _, m := range archiversvcServer.Mounts {
-			logger.Log("info", fmt.Sprintf("method %s mounted on %s %s", m.Method, m.Verb, m.Pattern))
-		}
-		for _, m := range healthServer.Mounts {
-			logger.Log("info", fmt.Sprintf("method %s mounted on %s %s", m.Method, m.Verb, m.Pattern))
+	// Serve the JSON-RPC requests made to the services.
+	archiversvckitrpc.Mount(mux, archiversvckitrpc.NewServer(archiverEndpoints))
+	healthkitrpc.Mount(mux, healthkitrpc.NewServer(healthEndpoints))
+
+	// Run the servers and the signal handler as the actors of a group, the
+	// first actor to return interrupts the others so that the process stops
+	// gracefully.
+	var g run.Group
+	{
+		// Wrap the multiplexer with additional middlewares. Middlewares
+		// mounted here apply to all the service endpoints. The request ID
+		// middleware must wrap the others so that the request logs and the
+		// error handler can read the request ID.
+		var handler http.Handler = mux
+		{
+			// Let the mock implementations return the errors selected
+			// with the X-Mock-Error request header.
+			if *mock {
+				handler = kitmock.Handler(handler)
+			}
+			handler = middleware.Log(adapter)(handler)
+			handler = middleware.RequestID()(handler)
 		}
-		logger.Log("listening", *addr)
-		errc <- srv.ListenAndServe()
-	}()
 
-	// Wait for signal.
-	logger.Log("exiting", <-errc)
+		// Start HTTP server using default configuration, change the code
+		// to configure the server as required by your service. The listen
+		// error is returned by the actor so that the group stops the
+		// other actors.
+		httpListener, err := net.Listen("tcp", *addr)
+		srv := &http.Server{Handler: handler}
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			for _, m := range archiverServer.Mounts {
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", archiverServer.Service(), m.Method, m.Verb, m.Pattern))
+			}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", archiverServer.Service(), archiversvckitrpc.Path))
+			for _, m := range healthServer.Mounts {
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", healthServer.Service(), m.Method, m.Verb, m.Pattern))
+			}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", healthServer.Service(), healthkitrpc.Path))
+			logger.Log("transport", "HTTP", "addr", *addr)
+			return srv.Serve(httpListener)
+		}, func(error) {
+			// Shutdown gracefully with a 30s timeout.
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Log("transport", "HTTP", "during", "Shutdown", "error", err)
+			}
+		})
+	}
+	{
+		// Serve the pprof handlers registered on the default mux on a
+		// separate listener so that they are not exposed publicly.
+		debugListener, err := net.Listen("tcp", *debugAddr)
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "debug/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "debug/HTTP", "addr", *debugAddr)
+			return http.Serve(debugListener, http.DefaultServeMux)
+		}, func(error) {
+			if debugListener != nil {
+				debugListener.Close()
+			}
+		})
+	}
+	{
+		// Serve the Prometheus metrics on a separate listener, the
+		// metrics include the request count, duration and errors of the
+		// service endpoints.
+		metricsListener, err := net.Listen("tcp", *metricsAddr)
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "metrics/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "metrics/HTTP", "addr", *metricsAddr)
+			return http.Serve(metricsListener, metricsMux)
+		}, func(error) {
+			if metricsListener != nil {
+				metricsListener.Close()
+			}
+		})
+	}
+	{
+		// Stop the servers gracefully when the process receives SIGINT or
+		// SIGTERM.
+		cancelInterrupt := make(chan struct{})
+		g.Add(func() error {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+			select {
+			case sig := <-c:
+				return fmt.Errorf("received signal %s", sig)
+			case <-cancelInterrupt:
+				return nil
+			}
+		}, func(error) {
+			close(cancelInterrupt)
+		})
+	}
+
+	// Run the group until one of the actors returns.
+	logger.Log("exiting", g.Run())
 
-	// Shutdown gracefully with a 30s timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
+	// Log the spans recorded by the memory tracer.
+	if mt, ok := tracer.(*mocktracer.MockTracer); ok {
+		for _, span := range mt.FinishedSpans() {
+			logger.Log("span", span.OperationName, "took", span.FinishTime.Sub(span.StartTime))
+		}
+	}
 
 	logger.Log("server", "exited")
 }
 
 // ErrorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
-// to correlate.
+// to correlate. The ID is "-" if the request ID middleware did not run.
 func ErrorHandler(logger log.Logger) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
-		id := ctx.Value(middleware.RequestIDKey).(string)
+		id, ok := ctx.Value(middleware.RequestIDKey).(string)
+		if !ok {
+			id = "-"
+		}
 		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
 		logger.Log("error", fmt.Sprintf("[%s] ERROR: %s", id, err.Error()))
 	}
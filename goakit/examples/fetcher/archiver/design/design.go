@@ -1,9 +1,11 @@
 package design
 
 import (
+	"time"
+
 	. "goa.design/goa/http/design"
-	. "goa.design/goa/http/dsl"
 	_ "goa.design/plugins/goakit"
+	. "goa.design/plugins/goakit/dsl"
 )
 
 var _ = API("archiver", func() {
@@ -13,6 +15,8 @@ var _ = API("archiver", func() {
 })
 
 var _ = Service("archiver", func() {
+	Mock()
+
 	HTTP(func() {
 		Path("/archive")
 	})
@@ -38,6 +42,10 @@ var _ = Service("archiver", func() {
 		Result(ArchiveMedia)
 		Error("not_found")
 		Error("bad_request")
+		Idempotent()
+		Timeout(5 * time.Second)
+		Retry(2)
+		CircuitBreaker("gobreaker")
 		HTTP(func() {
 			GET("/{id}")
 			Response(StatusOK)
@@ -67,7 +75,7 @@ var ArchiveMedia = ResultType("application/vnd.goa.archive", func() {
 	Attributes(func() {
 		Attribute("href", String, "The archive resouce href", func() {
 			Pattern("^/archive/[0-9]+$")
-			Example("/archive1/")
+			Example("/archive/1")
 		})
 		Attribute("status")
 		Attribute("body")
@@ -87,7 +95,9 @@ var _ = Service("health", func() {
 
 	Method("show", func() {
 		Description("Health check endpoint")
-		Result(String)
+		Result(String, func() {
+			Example("OK")
+		})
 		HTTP(func() {
 			GET("/")
 			Response(func() {
@@ -11,6 +11,7 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 // Endpoints wraps the "archiver" service endpoints.
@@ -33,6 +34,26 @@ func (e *Endpoints) Use(m func(endpoint.Endpoint) endpoint.Endpoint) {
 	e.Read = m(e.Read)
 }
 
+// Apply applies the middlewares returned by m to the "archiver" service
+// endpoints. m is called with the name of the method implemented by each
+// endpoint as defined in the design. The endpoints for which m returns nil are
+// left unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("archive"); mw != nil {
+		e.Archive = mw(e.Archive)
+	}
+	if mw := m("read"); mw != nil {
+		e.Read = mw(e.Read)
+	}
+}
+
+// UseGoa applies the goa endpoint middleware m to the "archiver" service
+// endpoints so that the middlewares written for the services generated without
+// goakit may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+
 // NewArchiveEndpoint returns an endpoint function that calls the method
 // "archive" of service "archiver".
 func NewArchiveEndpoint(s Service) endpoint.Endpoint {
@@ -49,10 +49,14 @@ func DecodeReadRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.De
 }
 
 // EncodeReadError returns a go-kit EncodeResponseFunc suitable for encoding
-// errors returned by the archiver read endpoint.
+// errors returned by the archiver read endpoint. The errors wrapped with
+// NewReadError are unwrapped before being encoded.
 func EncodeReadError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
 	enc := server.EncodeReadError(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
@@ -4,23 +4,34 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
 	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/oklog/run"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	goahttp "goa.design/goa/http"
 	"goa.design/goa/http/middleware"
 	fetcher "goa.design/plugins/goakit/examples/fetcher/fetcher"
 	fetchersvc "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/fetcher"
 	health "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/health"
+	fetchersvckitrpc "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/fetcher/kitjsonrpc"
 	fetchersvckitsvr "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/fetcher/kitserver"
 	fetchersvcsvr "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/fetcher/server"
+	healthkitrpc "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/health/kitjsonrpc"
 	healthkitsvr "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/health/kitserver"
 	healthsvr "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/http/health/server"
+	"goa.design/plugins/goakit/examples/fetcher/fetcher/gen/kitmetrics"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 func main() {
@@ -28,7 +39,10 @@ func main() {
 	// the service.
 	var (
 		addr         = flag.String("listen", ":8080", "HTTP listen `address`")
-		archiverHost = flag.String("archiver", ":8081", "archiver service `host:port`")
+		archiverHost = flag.String("archiver", ":8081", "comma separated list of archiver service `host:port`")
+		debugAddr    = flag.String("debug-listen", ":8082", "debug and pprof HTTP listen `address`")
+		metricsAddr  = flag.String("metrics-listen", ":8083", "Prometheus metrics HTTP listen `address`")
+		tracerName   = flag.String("tracer", "noop", "`tracer` used to record the request spans (noop or memory)")
 	)
 	flag.Parse()
 	if *archiverHost == "" {
@@ -36,35 +50,74 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Setup logger.
+	// Setup logger and goa log adapter. The go-kit loggers implement the
+	// goa middleware.Logger interface so that the adapter logs the HTTP
+	// requests with the go-kit logger.
 	var (
-		logger log.Logger
+		logger  log.Logger
+		adapter middleware.Logger
 	)
 	{
 		logger = log.NewLogfmtLogger(os.Stderr)
 		logger = log.With(logger, "ts", log.DefaultTimestampUTC)
 		logger = log.With(logger, "caller", log.DefaultCaller)
+		adapter = log.With(logger, "transport", "HTTP")
+	}
+
+	// Setup tracer. The memory tracer records the spans in process, replace
+	// it with an OpenTracing tracer such as Jaeger or Zipkin to export the
+	// spans.
+	var (
+		tracer opentracing.Tracer
+	)
+	{
+		switch *tracerName {
+		case "noop":
+			tracer = opentracing.NoopTracer{}
+		case "memory":
+			tracer = mocktracer.New()
+		default:
+			logger.Log("error", fmt.Sprintf("invalid tracer %q", *tracerName))
+			os.Exit(1)
+		}
+	}
+
+	// Create the go-kit Prometheus metrics served by the metrics listener.
+	var (
+		metrics *kitmetrics.Metrics
+	)
+	{
+		metrics = kitmetrics.New()
 	}
 
 	// Create the structs that implement the services.
 	var (
-		healths     health.Service
-		fetchersvcs fetchersvc.Service
+		healthSvc  health.Service
+		fetcherSvc fetchersvc.Service
 	)
 	{
-		healths = fetcher.NewHealth(logger)
-		fetchersvcs = fetcher.NewFetcher(logger, *archiverHost)
+		healthSvc = fetcher.NewHealth(logger)
+		healthSvc = health.LoggingMiddleware(logger)(healthSvc)
+		fetcherSvc = fetcher.NewFetcher(logger, strings.Split(*archiverHost, ","))
+		fetcherSvc = fetchersvc.LoggingMiddleware(logger)(fetcherSvc)
 	}
 
 	// Wrap the services in endpoints that can be invoked from other
-	// services potentially running in different processes.
+	// services potentially running in different processes. The error
+	// middlewares are applied first so that the tracing and metrics
+	// middlewares observe the errors defined in the design.
 	var (
-		healthe     *health.Endpoints
-		fetchersvce *fetchersvc.Endpoints
+		healthEndpoints  *health.Endpoints
+		fetcherEndpoints *fetchersvc.Endpoints
 	)
 	{
-		healthe = health.NewEndpoints(healths)
-		fetchersvce = fetchersvc.NewEndpoints(fetchersvcs)
+		healthEndpoints = health.NewEndpoints(healthSvc)
+		healthEndpoints.Apply(kitendpoint.Tracing(tracer, health.ServiceName))
+		healthEndpoints.Apply(metrics.Middleware(health.ServiceName))
+		fetcherEndpoints = fetchersvc.NewEndpoints(fetcherSvc)
+		fetcherEndpoints.Fetch = fetchersvckitsvr.FetchErrorMiddleware(fetcherEndpoints.Fetch)
+		fetcherEndpoints.Apply(kitendpoint.Tracing(tracer, fetchersvc.ServiceName))
+		fetcherEndpoints.Apply(metrics.Middleware(fetchersvc.ServiceName))
 	}
 
 	// Provide the transport specific request decoder and response encoder.
@@ -84,75 +137,150 @@ func main() {
 
 	// Wrap the endpoints with the transport specific layer.
 	var (
-		healthShowHandler      *kithttp.Server
-		healthServer           *healthsvr.Server
-		fetchersvcFetchHandler *kithttp.Server
-		fetchersvcServer       *fetchersvcsvr.Server
+		healthShowHandler   *kithttp.Server
+		healthServer        *healthsvr.Server
+		fetcherFetchHandler *kithttp.Server
+		fetcherServer       *fetchersvcsvr.Server
 	)
 	{
 		eh := ErrorHandler(logger)
-		healthShowHandler = kithttp.NewServer(
-			endpoint.Endpoint(healthe.Show),
-			func(context.Context, *http.Request) (request interface{}, err error) { return nil, nil },
-			healthkitsvr.EncodeShowResponse(enc),
-		)
-		healthServer = healthsvr.New(healthe, mux, dec, enc, eh)
-		fetchersvcFetchHandler = kithttp.NewServer(
-			endpoint.Endpoint(fetchersvce.Fetch),
-			fetchersvckitsvr.DecodeFetchRequest(mux, dec),
-			fetchersvckitsvr.EncodeFetchResponse(enc),
-		)
-		fetchersvcServer = fetchersvcsvr.New(fetchersvce, mux, dec, enc, eh)
+		healthShowHandler = healthkitsvr.NewShowServer(healthEndpoints.Show, mux, dec, enc, healthkitsvr.ShowTraceServerBefore(tracer, logger))
+		healthServer = healthsvr.New(healthEndpoints, mux, dec, enc, eh)
+		fetcherFetchHandler = fetchersvckitsvr.NewFetchServer(fetcherEndpoints.Fetch, mux, dec, enc, fetchersvckitsvr.FetchTraceServerBefore(tracer, logger))
+		fetcherServer = fetchersvcsvr.New(fetcherEndpoints, mux, dec, enc, eh)
 	}
 
 	// Configure the mux.
 	healthkitsvr.MountShowHandler(mux, healthShowHandler)
-	fetchersvckitsvr.MountFetchHandler(mux, fetchersvcFetchHandler)
-
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the service to stop gracefully.
-	go func() {
-		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
-	}()
-
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: *addr, Handler: mux}
-	go func() {
-		for _, m := range healthServer.Mounts {
-			logger.Log("info", fmt.Sprintf("method %s mounted on %s %s", m.Method, m.Verb, m.Pattern))
-		}
-		for _, m := range fetchersvcServer.Mounts {
-			logger.Log("info", fmt.Sprintf("method %s mounted on %s %s", m.Method, m.Verb, m.Pattern))
+	fetchersvckitsvr.MountFetchHandler(mux, fetcherFetchHandler)
+
+	// Serve the JSON-RPC requests made to the services.
+	healthkitrpc.Mount(mux, healthkitrpc.NewServer(healthEndpoints))
+	fetchersvckitrpc.Mount(mux, fetchersvckitrpc.NewServer(fetcherEndpoints))
+
+	// Run the servers and the signal handler as the actors of a group, the
+	// first actor to return interrupts the others so that the process stops
+	// gracefully.
+	var g run.Group
+	{
+		// Wrap the multiplexer with additional middlewares. Middlewares
+		// mounted here apply to all the service endpoints. The request ID
+		// middleware must wrap the others so that the request logs and the
+		// error handler can read the request ID.
+		var handler http.Handler = mux
+		{
+			handler = middleware.Log(adapter)(handler)
+			handler = middleware.RequestID()(handler)
 		}
-		logger.Log("listening", *addr)
-		errc <- srv.ListenAndServe()
-	}()
 
-	// Wait for signal.
-	logger.Log("exiting", <-errc)
+		// Start HTTP server using default configuration, change the code
+		// to configure the server as required by your service. The listen
+		// error is returned by the actor so that the group stops the
+		// other actors.
+		httpListener, err := net.Listen("tcp", *addr)
+		srv := &http.Server{Handler: handler}
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			for _, m := range healthServer.Mounts {
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", healthServer.Service(), m.Method, m.Verb, m.Pattern))
+			}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", healthServer.Service(), healthkitrpc.Path))
+			for _, m := range fetcherServer.Mounts {
+				logger.Log("info", fmt.Sprintf("service %s method %s mounted on %s %s", fetcherServer.Service(), m.Method, m.Verb, m.Pattern))
+			}
+			logger.Log("info", fmt.Sprintf("service %s JSON-RPC methods mounted on POST %s", fetcherServer.Service(), fetchersvckitrpc.Path))
+			logger.Log("transport", "HTTP", "addr", *addr)
+			return srv.Serve(httpListener)
+		}, func(error) {
+			// Shutdown gracefully with a 30s timeout.
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := srv.Shutdown(ctx); err != nil {
+				logger.Log("transport", "HTTP", "during", "Shutdown", "error", err)
+			}
+		})
+	}
+	{
+		// Serve the pprof handlers registered on the default mux on a
+		// separate listener so that they are not exposed publicly.
+		debugListener, err := net.Listen("tcp", *debugAddr)
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "debug/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "debug/HTTP", "addr", *debugAddr)
+			return http.Serve(debugListener, http.DefaultServeMux)
+		}, func(error) {
+			if debugListener != nil {
+				debugListener.Close()
+			}
+		})
+	}
+	{
+		// Serve the Prometheus metrics on a separate listener, the
+		// metrics include the request count, duration and errors of the
+		// service endpoints.
+		metricsListener, err := net.Listen("tcp", *metricsAddr)
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		g.Add(func() error {
+			if err != nil {
+				logger.Log("transport", "metrics/HTTP", "during", "Listen", "error", err)
+				return err
+			}
+			logger.Log("transport", "metrics/HTTP", "addr", *metricsAddr)
+			return http.Serve(metricsListener, metricsMux)
+		}, func(error) {
+			if metricsListener != nil {
+				metricsListener.Close()
+			}
+		})
+	}
+	{
+		// Stop the servers gracefully when the process receives SIGINT or
+		// SIGTERM.
+		cancelInterrupt := make(chan struct{})
+		g.Add(func() error {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+			select {
+			case sig := <-c:
+				return fmt.Errorf("received signal %s", sig)
+			case <-cancelInterrupt:
+				return nil
+			}
+		}, func(error) {
+			close(cancelInterrupt)
+		})
+	}
+
+	// Run the group until one of the actors returns.
+	logger.Log("exiting", g.Run())
 
-	// Shutdown gracefully with a 30s timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
+	// Log the spans recorded by the memory tracer.
+	if mt, ok := tracer.(*mocktracer.MockTracer); ok {
+		for _, span := range mt.FinishedSpans() {
+			logger.Log("span", span.OperationName, "took", span.FinishTime.Sub(span.StartTime))
+		}
+	}
 
 	logger.Log("server", "exited")
 }
 
 // ErrorHandler returns a function that writes and logs the given error.
 // The function also writes and logs the error unique ID so that it's possible
-// to correlate.
+// to correlate. The ID is "-" if the request ID middleware did not run.
 func ErrorHandler(logger log.Logger) func(context.Context, http.ResponseWriter, error) {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
-		id := ctx.Value(middleware.RequestIDKey).(string)
+		id, ok := ctx.Value(middleware.RequestIDKey).(string)
+		if !ok {
+			id = "-"
+		}
 		w.Write([]byte("[" + id + "] encoding: " + err.Error()))
-		logger.Log("info", fmt.Sprintf("[%s] ERROR: %s", id, err.Error()))
+		logger.Log("error", fmt.Sprintf("[%s] ERROR: %s", id, err.Error()))
 	}
 }
@@ -59,7 +59,9 @@ var _ = Service("health", func() {
 	})
 	Method("show", func() {
 		Description("Health check endpoint")
-		Result(String)
+		Result(String, func() {
+			Example("OK")
+		})
 		HTTP(func() {
 			GET("/")
 			Response(func() {
@@ -5,15 +5,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
-	kithttp "github.com/go-kit/kit/transport/http"
-	goahttp "goa.design/goa/http"
+	"github.com/go-kit/kit/sd"
 	archiversvc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/archiver"
 	archiverkc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/kitclient"
-	archiverks "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/server"
 	fetchersvc "goa.design/plugins/goakit/examples/fetcher/fetcher/gen/fetcher"
 )
 
@@ -24,24 +21,11 @@ type fetchersvcsvc struct {
 	archive endpoint.Endpoint
 }
 
-// NewFetcher returns the fetcher service implementation.
-func NewFetcher(logger log.Logger, archiverHost string) fetchersvc.Service {
-	u := url.URL{
-		Scheme: "http",
-		Host:   archiverHost,
-		Path:   archiverks.ArchiveArchiverPath(),
-	}
-	var (
-		dec = goahttp.ResponseDecoder
-		enc = goahttp.RequestEncoder
-	)
-	arc := kithttp.NewClient(
-		"POST",
-		&u,
-		archiverkc.EncodeArchiveRequest(enc),
-		archiverkc.DecodeArchiveResponse(dec),
-	)
-	return &fetchersvcsvc{logger: logger, archive: arc.Endpoint()}
+// NewFetcher returns the fetcher service implementation. The requests made to
+// the archiver service are balanced across the given hosts.
+func NewFetcher(logger log.Logger, archiverHosts []string) fetchersvc.Service {
+	arc := archiverkc.NewLoadBalancedEndpoints(sd.FixedInstancer(archiverHosts), logger, nil)
+	return &fetchersvcsvc{logger: logger, archive: arc.Archive}
 }
 
 // Fetch makes a GET request to the given URL and stores the results in the
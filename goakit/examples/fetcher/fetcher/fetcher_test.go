@@ -0,0 +1,115 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/sd"
+	archiversvc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/archiver"
+	archiverkc "goa.design/plugins/goakit/examples/fetcher/archiver/gen/http/archiver/kitclient"
+)
+
+// archiverInstance is an archiver service instance that counts the requests
+// it receives.
+type archiverInstance struct {
+	*httptest.Server
+	requests int32
+}
+
+// newArchiverInstance starts an archiver service instance that responds to
+// all the requests with an archive or with an internal error if failing is
+// true.
+func newArchiverInstance(failing bool) *archiverInstance {
+	i := &archiverInstance{}
+	i.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&i.requests, 1)
+		if failing {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"href":"/archive/1","status":200,"body":"archived"}`)
+	}))
+	return i
+}
+
+func (i *archiverInstance) count() int {
+	return int(atomic.LoadInt32(&i.requests))
+}
+
+// newArchiverEndpoints returns the load balanced archiver endpoints that make
+// requests to the given instances.
+func newArchiverEndpoints(instances ...*archiverInstance) *archiversvc.Endpoints {
+	urls := make([]string, len(instances))
+	for i, inst := range instances {
+		urls[i] = inst.URL
+	}
+	return archiverkc.NewLoadBalancedEndpoints(sd.FixedInstancer(urls), log.NewNopLogger(), nil)
+}
+
+func TestLoadBalancedRoundRobin(t *testing.T) {
+	instances := []*archiverInstance{newArchiverInstance(false), newArchiverInstance(false), newArchiverInstance(false)}
+	for _, i := range instances {
+		defer i.Close()
+	}
+	eps := newArchiverEndpoints(instances...)
+	for n := 0; n < 2*len(instances); n++ {
+		res, err := eps.Read(context.Background(), &archiversvc.ReadPayload{ID: 1})
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %s", n, err)
+		}
+		if href := res.(*archiversvc.ArchiveMedia).Href; href != "/archive/1" {
+			t.Errorf("request %d: got href %q, expected %q", n, href, "/archive/1")
+		}
+	}
+	for n, i := range instances {
+		if i.count() != 2 {
+			t.Errorf("instance %d: got %d requests, expected 2", n, i.count())
+		}
+	}
+}
+
+func TestLoadBalancedRetry(t *testing.T) {
+	failing, healthy := newArchiverInstance(true), newArchiverInstance(false)
+	defer failing.Close()
+	defer healthy.Close()
+	eps := newArchiverEndpoints(failing, healthy)
+	// The round-robin balancer attempts at least one of the two requests on
+	// the failing instance first, the request is then retried on the healthy
+	// instance.
+	for n := 0; n < 2; n++ {
+		if _, err := eps.Read(context.Background(), &archiversvc.ReadPayload{ID: 1}); err != nil {
+			t.Fatalf("request %d: unexpected error: %s", n, err)
+		}
+	}
+	if failing.count() == 0 {
+		t.Error("failing instance: got no request, expected at least one")
+	}
+	if healthy.count() != 2 {
+		t.Errorf("healthy instance: got %d requests, expected 2", healthy.count())
+	}
+}
+
+func TestLoadBalancedNonIdempotent(t *testing.T) {
+	instances := []*archiverInstance{newArchiverInstance(true), newArchiverInstance(true)}
+	for _, i := range instances {
+		defer i.Close()
+	}
+	eps := newArchiverEndpoints(instances...)
+	_, err := eps.Archive(context.Background(), &archiversvc.ArchivePayload{Status: 200, Body: "archived"})
+	if err == nil {
+		t.Fatal("got no error, expected the request to fail")
+	}
+	var total int
+	for _, i := range instances {
+		total += i.count()
+	}
+	if total != 1 {
+		t.Errorf("got %d requests, expected a single attempt", total)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 // Endpoints wraps the "fetcher" service endpoints.
@@ -30,6 +31,23 @@ func (e *Endpoints) Use(m func(endpoint.Endpoint) endpoint.Endpoint) {
 	e.Fetch = m(e.Fetch)
 }
 
+// Apply applies the middlewares returned by m to the "fetcher" service
+// endpoints. m is called with the name of the method implemented by each
+// endpoint as defined in the design. The endpoints for which m returns nil are
+// left unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("fetch"); mw != nil {
+		e.Fetch = mw(e.Fetch)
+	}
+}
+
+// UseGoa applies the goa endpoint middleware m to the "fetcher" service
+// endpoints so that the middlewares written for the services generated without
+// goakit may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+
 // NewFetchEndpoint returns an endpoint function that calls the method "fetch"
 // of service "fetcher".
 func NewFetchEndpoint(s Service) endpoint.Endpoint {
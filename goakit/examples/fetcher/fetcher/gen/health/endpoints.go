@@ -11,6 +11,7 @@ import (
 	"context"
 
 	"github.com/go-kit/kit/endpoint"
+	"goa.design/plugins/goakit/kitendpoint"
 )
 
 // Endpoints wraps the "health" service endpoints.
@@ -30,6 +31,23 @@ func (e *Endpoints) Use(m func(endpoint.Endpoint) endpoint.Endpoint) {
 	e.Show = m(e.Show)
 }
 
+// Apply applies the middlewares returned by m to the "health" service
+// endpoints. m is called with the name of the method implemented by each
+// endpoint as defined in the design. The endpoints for which m returns nil are
+// left unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("show"); mw != nil {
+		e.Show = mw(e.Show)
+	}
+}
+
+// UseGoa applies the goa endpoint middleware m to the "health" service
+// endpoints so that the middlewares written for the services generated without
+// goakit may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+
 // NewShowEndpoint returns an endpoint function that calls the method "show" of
 // service "health".
 func NewShowEndpoint(s Service) endpoint.Endpoint {
@@ -33,10 +33,14 @@ func DecodeFetchRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.D
 }
 
 // EncodeFetchError returns a go-kit EncodeResponseFunc suitable for encoding
-// errors returned by the fetcher fetch endpoint.
+// errors returned by the fetcher fetch endpoint. The errors wrapped with
+// NewFetchError are unwrapped before being encoded.
 func EncodeFetchError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
 	enc := server.EncodeFetchError(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
@@ -1,54 +1,112 @@
 package goakit
 
 import (
+	"bytes"
 	"fmt"
-	"regexp"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"goa.design/goa/codegen"
 	"goa.design/goa/eval"
+	grpcdesign "goa.design/goa/grpc/design"
+	httpcodegen "goa.design/goa/http/codegen"
 	httpdesign "goa.design/goa/http/design"
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 // Register the plugin Generator functions.
 func init() {
 	codegen.RegisterPluginFirst("goakit", "gen", Generate)
 	codegen.RegisterPluginFirst("goakit", "example", Example)
-	codegen.RegisterPluginLast("goakit-goakitify", "gen", Goakitify)
 }
 
-// Generate generates go-kit specific decoders and encoders.
+// Generate generates the go-kit service middlewares and Prometheus metrics
+// together with go-kit specific decoders, encoders and clients for the HTTP and
+// gRPC transports. The security requirements defined with the security plugin
+// DSL are enforced with the go-kit auth middlewares.
+// The files generated by goa and goakit use the go-kit endpoint type, the files
+// generated by the plugins that run after goakit are left untouched.
 func Generate(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	addEndpointsApply(files)
+	files = append(files, MiddlewareFiles(genpkg)...)
+	files = append(files, MetricsFiles()...)
 	for _, root := range roots {
-		if r, ok := root.(*httpdesign.RootExpr); ok {
+		switch r := root.(type) {
+		case *httpdesign.RootExpr:
+			warnStreaming(r)
 			files = append(files, EncodeDecodeFiles(genpkg, r)...)
+			files = append(files, ClientFiles(genpkg, r)...)
+			files = append(files, SDFiles(genpkg, r)...)
+			files = append(files, ClientMiddlewareFiles(r)...)
+			files = append(files, ServerFiles(genpkg, r)...)
+			files = append(files, TracingFiles(r)...)
+			files = append(files, JSONRPCFiles(genpkg, r)...)
+			files = append(files, NATSFiles(genpkg, r)...)
+			files = append(files, ErrorFiles(genpkg, r)...)
 			files = append(files, MountFiles(r)...)
+			files = append(files, SecurityFiles(genpkg, r)...)
+		case *grpcdesign.RootExpr:
+			files = append(files, GRPCFiles(genpkg, r)...)
 		}
 	}
-	return files, nil
+	return Goakitify(genpkg, roots, files)
 }
 
-// Goakitify modifies all the previously generated files by replacing all
-// instances of "goa.Endpoint" with "github.com/go-kit/kit/endpoint".Endpoint
-// and adding the corresponding import.
-func Goakitify(enpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+// Goakitify modifies the given Go files so that they use the
+// "github.com/go-kit/kit/endpoint".Endpoint type instead of goa.Endpoint. The
+// files are rewritten once rendered using their syntax tree so that only the
+// references to the goa package Endpoint type change, including the sections
+// added to the files by other plugins.
+func Goakitify(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
 	for _, f := range files {
-		goakitify(f)
+		if filepath.Ext(f.Path) != ".go" {
+			continue
+		}
+		finalize := f.FinalizeFunc
+		f.FinalizeFunc = func(path string) error {
+			if finalize != nil {
+				if err := finalize(path); err != nil {
+					return err
+				}
+			}
+			return goakitifyFile(path)
+		}
 	}
 	return files, nil
 }
 
 // Example iterates through the roots and returns files that implement an
-// example service and client.
+// example service and client together with the mock implementations of the
+// services that enable them with the Mock DSL. The example server uses the
+// mock implementations when started with the -mock flag. The example client
+// command line tool uses the go-kit HTTP clients.
 func Example(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
-	var examples []*codegen.File
+	var (
+		httpRoot *httpdesign.RootExpr
+		grpcRoot *grpcdesign.RootExpr
+	)
 	for _, root := range roots {
-		if r, ok := root.(*httpdesign.RootExpr); ok {
-			examples = ExampleServerFiles(genpkg, r)
-			break
+		switch r := root.(type) {
+		case *httpdesign.RootExpr:
+			httpRoot = r
+		case *grpcdesign.RootExpr:
+			grpcRoot = r
 		}
 	}
-	if len(examples) == 0 {
-		return nil, fmt.Errorf("example: no HTTP design found")
+	if httpRoot == nil && grpcRoot == nil {
+		return nil, fmt.Errorf("example: no HTTP or gRPC design found")
+	}
+	examples := ExampleServerFiles(genpkg, httpRoot, grpcRoot)
+	examples = append(examples, ExampleMockFiles(genpkg)...)
+	if httpRoot != nil {
+		examples = append(examples, ExampleCLIFiles(genpkg, httpRoot)...)
 	}
 	// Remove previously generated example files.
 	var output []*codegen.File
@@ -68,25 +126,135 @@ func Example(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codege
 	return output, nil
 }
 
-// goaEndpointRegexp matches occurrences of the "goa.Endpoint" type in Go code.
-var goaEndpointRegexp = regexp.MustCompile(`([^\p{L}_])goa\.Endpoint([^\p{L}_])`)
+// warnStreaming notifies the user that goakit generates no go-kit transport
+// for the streaming methods defined in the design. The goa generated WebSocket
+// handlers serve these methods.
+func warnStreaming(root *httpdesign.RootExpr) {
+	if ms := streamingMethods(root); len(ms) > 0 {
+		fmt.Fprintf(os.Stderr, "goakit: no go-kit transport generated for the streaming methods %s, the goa WebSocket handlers serve them\n", strings.Join(ms, ", "))
+	}
+}
+
+// streamingMethods returns the "service.method" names of the streaming
+// methods defined in the given root.
+func streamingMethods(root *httpdesign.RootExpr) []string {
+	var ms []string
+	for _, svc := range root.HTTPServices {
+		for _, e := range httpcodegen.HTTPServices.Get(svc.Name()).Endpoints {
+			if e.ServerStream != nil {
+				ms = append(ms, e.ServiceName+"."+e.Method.Name)
+			}
+		}
+	}
+	return ms
+}
+
+const (
+	// goaPkgPath is the import path of the package defining goa.Endpoint.
+	goaPkgPath = "goa.design/goa"
+	// kitEndpointPkgPath is the import path of the go-kit endpoint package.
+	kitEndpointPkgPath = "github.com/go-kit/kit/endpoint"
+)
+
+// goakitifyFile rewrites the Go file with the given path so that it uses the
+// go-kit endpoint type instead of goa.Endpoint.
+func goakitifyFile(path string) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	code, err := goakitify(src)
+	if err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	if bytes.Equal(code, src) {
+		return nil
+	}
+	return ioutil.WriteFile(path, code, 0644)
+}
 
-// goakitify replaces all occurrences of goa.Endpoint with endpoint.Endpoint in
-// the file section template sources. It also adds
-// "github.com/go-kit/kit/endpoint" to the list of imported packages if
-// occurrences were replaces.
-func goakitify(f *codegen.File) {
-	var hasEndpoint bool
-	for _, s := range f.SectionTemplates {
-		if !hasEndpoint {
-			hasEndpoint = goaEndpointRegexp.MatchString(s.Source)
+// goakitify returns the Go source code src with the references to the goa
+// Endpoint type replaced with references to the go-kit Endpoint type. The
+// references are looked up in the syntax tree using the name the goa package is
+// imported with so that comments, string literals and identifiers that shadow
+// the package are left untouched. The go-kit endpoint package is only imported
+// if src references goa.Endpoint and the goa package import is removed once
+// unused.
+func goakitify(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	goaName := importName(f, goaPkgPath)
+	if goaName == "" {
+		return src, nil
+	}
+	kitName := importName(f, kitEndpointPkgPath)
+	imported := kitName != ""
+	if !imported {
+		kitName = "endpoint"
+		if f.Scope.Lookup(kitName) != nil {
+			kitName = "kitendpoint"
+		}
+	}
+	var found bool
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Endpoint" {
+			return true
+		}
+		// Identifiers that refer to packages are not resolved by the parser.
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == goaName && id.Obj == nil {
+			id.Name = kitName
+			found = true
+		}
+		return true
+	})
+	if !found {
+		return src, nil
+	}
+	if !imported {
+		name := kitName
+		if name == "endpoint" {
+			name = ""
+		}
+		astutil.AddNamedImport(fset, f, name, kitEndpointPkgPath)
+	}
+	if !astutil.UsesImport(f, goaPkgPath) {
+		for _, imp := range f.Imports {
+			if p, _ := strconv.Unquote(imp.Path.Value); p == goaPkgPath {
+				var name string
+				if imp.Name != nil {
+					name = imp.Name.Name
+				}
+				astutil.DeleteNamedImport(fset, f, name, goaPkgPath)
+				break
+			}
 		}
-		s.Source = goaEndpointRegexp.ReplaceAllString(s.Source, "${1}endpoint.Endpoint${2}")
 	}
-	if hasEndpoint {
-		codegen.AddImport(
-			f.SectionTemplates[0],
-			&codegen.ImportSpec{Path: "github.com/go-kit/kit/endpoint"},
-		)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importName returns the name used by the given file to refer to the package
+// with the given import path, the empty string if the file does not import the
+// package or uses a dot or blank import.
+func importName(f *ast.File, path string) string {
+	for _, imp := range f.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p != path {
+			continue
+		}
+		if imp.Name != nil {
+			if imp.Name.Name == "." || imp.Name.Name == "_" {
+				return ""
+			}
+			return imp.Name.Name
+		}
+		return path[strings.LastIndex(path, "/")+1:]
 	}
+	return ""
 }
@@ -1,7 +1,9 @@
 package goakit
 
 import (
-	"bytes"
+	"io/ioutil"
+	"os"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -19,8 +21,8 @@ func TestGenerate(t *testing.T) {
 		DSL      func()
 		ExpFiles int
 	}{
-		"multi-endpoints": {testdata.MultiEndpointDSL, 3},
-		"multi-services":  {testdata.MultiServiceDSL, 6},
+		"multi-endpoints": {testdata.MultiEndpointDSL, 13},
+		"multi-services":  {testdata.MultiServiceDSL, 23},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -39,9 +41,15 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestStreamingMethods(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.StreamingDSL)
+	ms := streamingMethods(httpdesign.Root)
+	if len(ms) != 1 || ms[0] != "StreamingService.StreamingMethod" {
+		t.Errorf("got streaming methods %v, expected [StreamingService.StreamingMethod]", ms)
+	}
+}
+
 func TestGoakitify(t *testing.T) {
-	// Map all files containing goaEndpoint indexed by file path
-	goaEndpointFiles := map[string]bool{}
 	cases := map[string]func(){
 		"multi-endpoints": testdata.MultiEndpointDSL,
 		"multi-services":  testdata.MultiServiceDSL,
@@ -51,41 +59,109 @@ func TestGoakitify(t *testing.T) {
 			httpcodegen.RunHTTPDSL(t, dsl)
 			roots := []eval.Root{goadesign.Root, httpdesign.Root}
 			files := generateFiles(t, roots)
+			// Before state: collect all files with goa endpoints.
+			goaEndpointFiles := make(map[string]bool)
+			for _, f := range files {
+				goaEndpointFiles[f.Path] = containsGoaEndpoint(f)
+			}
 			newFiles, err := Goakitify("", roots, files)
 			if err != nil {
 				t.Fatalf("generate error: %v", err)
 			}
-			// Before state: Collect all files with goa endpoint.
+			// After state: the rendered files use go-kit endpoints.
+			dir := tempDir(t)
+			defer os.RemoveAll(dir)
 			for _, f := range newFiles {
-				goaEndpointFiles[f.Path] = containsGoaEndpoint(f)
-			}
-			// After state: files with goa endpoint should be replaced by gokit endpoint
-			for _, f := range files {
-				if goaEndpointFiles[f.Path] {
-					if containsGoaEndpoint(f) {
-						t.Errorf("file %s still has goa endpoints", f.Path)
-					}
-					buf := new(bytes.Buffer)
-					if err := f.SectionTemplates[0].Write(buf); err != nil {
-						t.Fatalf("error writing section in file %s", f.Path)
-					}
-					code := buf.String()
-					if !strings.Contains(code, "github.com/go-kit/kit/endpoint") {
-						t.Errorf("go-kit not imported in file %s:\n%s", f.Path, code)
-					}
+				if !goaEndpointFiles[f.Path] {
+					continue
+				}
+				code := renderFile(t, dir, f)
+				if strings.Contains(code, "goa.Endpoint") {
+					t.Errorf("file %s still has goa endpoints:\n%s", f.Path, code)
+				}
+				if !strings.Contains(code, `"github.com/go-kit/kit/endpoint"`) {
+					t.Errorf("go-kit not imported in file %s:\n%s", f.Path, code)
 				}
 			}
 		})
 	}
 }
 
+func TestGoakitifyPluginFiles(t *testing.T) {
+	header := func() *codegen.SectionTemplate {
+		return codegen.Header("", "foo", []*codegen.ImportSpec{{Path: "goa.design/goa", Name: "goa"}})
+	}
+	goaFile := &codegen.File{
+		Path: "goa.go",
+		SectionTemplates: []*codegen.SectionTemplate{
+			header(),
+			{Name: "goa-endpoints", Source: "type Endpoints struct {\n\tAdd goa.Endpoint\n}\n"},
+		},
+	}
+	files, err := Goakitify("", nil, []*codegen.File{goaFile})
+	if err != nil {
+		t.Fatalf("goakitify error: %v", err)
+	}
+	// A plugin running after goakit adds a section to the goa file and
+	// generates its own file.
+	goaFile.SectionTemplates = append(goaFile.SectionTemplates, &codegen.SectionTemplate{
+		Name:   "plugin-use",
+		Source: "func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {\n\te.Add = m(e.Add)\n}\n",
+	})
+	pluginFile := &codegen.File{
+		Path: "plugin.go",
+		SectionTemplates: []*codegen.SectionTemplate{
+			header(),
+			{Name: "plugin-log", Source: "func Log(e goa.Endpoint) goa.Endpoint {\n\treturn e\n}\n"},
+		},
+	}
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	code := renderFile(t, dir, files[0])
+	for _, exp := range []string{"Add endpoint.Endpoint", "Use(m func(endpoint.Endpoint) endpoint.Endpoint)"} {
+		if !strings.Contains(code, exp) {
+			t.Errorf("goa file does not contain %q:\n%s", exp, code)
+		}
+	}
+	code = renderFile(t, dir, pluginFile)
+	if !strings.Contains(code, "func Log(e goa.Endpoint) goa.Endpoint") {
+		t.Errorf("plugin file was modified:\n%s", code)
+	}
+}
+
+func TestGoakitifySource(t *testing.T) {
+	cases := map[string]struct {
+		Code     string
+		Expected string
+	}{
+		"goa-endpoint":      {testdata.GoaEndpointCode, testdata.GoaEndpointGoakitifiedCode},
+		"aliased-goa":       {testdata.AliasedGoaEndpointCode, testdata.AliasedGoaEndpointGoakitifiedCode},
+		"comments-strings":  {testdata.CommentGoaEndpointCode, testdata.CommentGoaEndpointCode},
+		"shadowed-goa":      {testdata.ShadowedGoaEndpointCode, testdata.ShadowedGoaEndpointCode},
+		"kit-imported":      {testdata.KitImportedGoaEndpointCode, testdata.KitImportedGoaEndpointGoakitifiedCode},
+		"endpoint-declared": {testdata.EndpointDeclaredGoaEndpointCode, testdata.EndpointDeclaredGoaEndpointGoakitifiedCode},
+		"no-goa-import":     {testdata.NoGoaImportCode, testdata.NoGoaImportCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			code, err := goakitify([]byte(c.Code))
+			if err != nil {
+				t.Fatalf("goakitify error: %v", err)
+			}
+			if string(code) != c.Expected {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, string(code), c.Expected))
+			}
+		})
+	}
+}
+
 func TestExample(t *testing.T) {
 	cases := map[string]struct {
 		DSL      func()
 		ExpFiles int
 	}{
-		"mixed":          {testdata.MixedDSL, 2},
-		"multi-services": {testdata.MultiServiceDSL, 3},
+		"mixed":          {testdata.MixedDSL, 4},
+		"multi-services": {testdata.MultiServiceDSL, 6},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -117,6 +193,9 @@ func generateFiles(t *testing.T, roots []eval.Root) []*codegen.File {
 	return files
 }
 
+// goaEndpointRegexp matches occurrences of the "goa.Endpoint" type in Go code.
+var goaEndpointRegexp = regexp.MustCompile(`([^\p{L}_])goa\.Endpoint([^\p{L}_])`)
+
 func containsGoaEndpoint(f *codegen.File) bool {
 	for _, s := range f.SectionTemplates {
 		if goaEndpointRegexp.MatchString(s.Source) {
@@ -125,3 +204,23 @@ func containsGoaEndpoint(f *codegen.File) bool {
 	}
 	return false
 }
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "goakit")
+	if err != nil {
+		t.Fatalf("error creating temporary directory: %v", err)
+	}
+	return dir
+}
+
+func renderFile(t *testing.T, dir string, f *codegen.File) string {
+	path, err := f.Render(dir)
+	if err != nil {
+		t.Fatalf("error rendering file %s: %v", f.Path, err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("error reading file %s: %v", f.Path, err)
+	}
+	return string(b)
+}
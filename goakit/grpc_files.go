@@ -0,0 +1,381 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	grpccodegen "goa.design/goa/grpc/codegen"
+	grpcdesign "goa.design/goa/grpc/design"
+)
+
+// GRPCServiceData contains the data used to render the go-kit gRPC transport
+// templates of a service.
+type GRPCServiceData struct {
+	// Service is the service data.
+	Service *service.Data
+	// PBPkgName is the name of the package generated by protoc.
+	PBPkgName string
+	// FullName is the fully qualified name of the gRPC service as defined
+	// in the generated protocol buffer file.
+	FullName string
+	// ServerInterface is the name of the gRPC server interface generated by
+	// protoc.
+	ServerInterface string
+	// Methods lists the unary methods of the service. Streaming methods
+	// cannot be expressed with the go-kit request/response model and are
+	// omitted.
+	Methods []*service.MethodData
+}
+
+// GRPCFiles produces the go-kit gRPC server and client files that wrap the
+// goa generated gRPC encoders and decoders.
+func GRPCFiles(genpkg string, root *grpcdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.GRPCServices {
+		data := BuildGRPCServiceData(svc)
+		fw = append(fw,
+			grpcServerEncodeDecode(genpkg, data),
+			grpcServer(genpkg, data),
+			grpcClientEncodeDecode(genpkg, data),
+			grpcClient(genpkg, data),
+		)
+	}
+	return fw
+}
+
+// BuildGRPCServiceData builds the go-kit gRPC template data for the given
+// service.
+func BuildGRPCServiceData(svc *grpcdesign.ServiceExpr) *GRPCServiceData {
+	sd := service.Services.Get(svc.Name())
+	name := codegen.Goify(svc.Name(), true)
+	data := &GRPCServiceData{
+		Service:         sd,
+		PBPkgName:       grpccodegen.GRPCServices.Get(svc.Name()).PkgName,
+		FullName:        codegen.SnakeCase(svc.Name()) + "." + name,
+		ServerInterface: name + "Server",
+	}
+	for _, m := range sd.Methods {
+		if m.ServerStream != nil {
+			continue
+		}
+		data.Methods = append(data.Methods, m)
+	}
+	return data
+}
+
+// grpcServerEncodeDecode returns the file defining the go-kit gRPC server
+// decoding and encoding logic.
+func grpcServerEncodeDecode(genpkg string, data *GRPCServiceData) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "grpc", codegen.SnakeCase(data.Service.Name), "kitserver", "encode_decode.go")
+	title := fmt.Sprintf("%s go-kit gRPC server encoders and decoders", data.Service.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: genpkg + "/grpc/" + codegen.SnakeCase(data.Service.Name) + "/server"},
+		}),
+	}
+	for _, m := range data.Methods {
+		if m.Payload != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "goakit-grpc-request-decoder",
+				Source: grpcRequestDecoderT,
+				Data:   m,
+			})
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-grpc-response-encoder",
+			Source: grpcResponseEncoderT,
+			Data:   m,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// grpcServer returns the file defining the go-kit gRPC server that implements
+// the protoc generated server interface.
+func grpcServer(genpkg string, data *GRPCServiceData) *codegen.File {
+	svcName := codegen.SnakeCase(data.Service.Name)
+	path := filepath.Join(codegen.Gendir, "grpc", svcName, "kitserver", "server.go")
+	title := fmt.Sprintf("%s go-kit gRPC server", data.Service.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "github.com/go-kit/kit/transport/grpc", Name: "kitgrpc"},
+			{Path: "goa.design/goa/grpc", Name: "goagrpc"},
+			{Path: filepath.Join(genpkg, data.Service.Name), Name: data.Service.PkgName},
+			{Path: genpkg + "/grpc/" + svcName + "/pb", Name: data.PBPkgName},
+		}),
+		{
+			Name:   "goakit-grpc-server",
+			Source: grpcServerT,
+			Data:   data,
+		},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-grpc-server-method",
+			Source: grpcServerMethodT,
+			Data:   map[string]interface{}{"Service": data, "Method": m},
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// grpcClientEncodeDecode returns the file defining the go-kit gRPC client
+// decoding logic.
+func grpcClientEncodeDecode(genpkg string, data *GRPCServiceData) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "grpc", codegen.SnakeCase(data.Service.Name), "kitclient", "encode_decode.go")
+	title := fmt.Sprintf("%s go-kit gRPC client encoders and decoders", data.Service.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: genpkg + "/grpc/" + codegen.SnakeCase(data.Service.Name) + "/client"},
+		}),
+		{
+			Name:   "goakit-grpc-response-metadata",
+			Source: grpcResponseMetadataT,
+		},
+	}
+	for _, m := range data.Methods {
+		if m.Result != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "goakit-grpc-response-decoder",
+				Source: grpcResponseDecoderT,
+				Data:   m,
+			})
+		}
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// grpcClient returns the file defining the go-kit gRPC client constructors.
+func grpcClient(genpkg string, data *GRPCServiceData) *codegen.File {
+	svcName := codegen.SnakeCase(data.Service.Name)
+	path := filepath.Join(codegen.Gendir, "grpc", svcName, "kitclient", "client.go")
+	title := fmt.Sprintf("%s go-kit gRPC client", data.Service.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/transport/grpc", Name: "kitgrpc"},
+			{Path: "google.golang.org/grpc"},
+			{Path: "google.golang.org/grpc/metadata"},
+			{Path: filepath.Join(genpkg, data.Service.Name), Name: data.Service.PkgName},
+			{Path: genpkg + "/grpc/" + svcName + "/client"},
+			{Path: genpkg + "/grpc/" + svcName + "/pb", Name: data.PBPkgName},
+		}),
+		{
+			Name:   "goakit-grpc-client-new",
+			Source: grpcClientNewT,
+			Data:   data,
+		},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-grpc-client",
+			Source: grpcClientT,
+			Data:   map[string]interface{}{"Service": data, "Method": m},
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "goakit-grpc-client-helper",
+		Source: grpcClientHelperT,
+	})
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: MethodData
+const grpcRequestDecoderT = `{{ printf "Decode%sRequest is a go-kit DecodeRequestFunc suitable for decoding %s requests. It wraps the goa generated decoder and provides it with the incoming request metadata." .VarName .Name | comment }}
+func Decode{{ .VarName }}Request(ctx context.Context, v interface{}) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return server.Decode{{ .VarName }}Request(ctx, v, md)
+}
+`
+
+// input: MethodData
+const grpcResponseEncoderT = `{{ printf "Encode%sResponse is a go-kit EncodeResponseFunc suitable for encoding %s responses. It wraps the goa generated encoder and sets the response header and trailer metadata." .VarName .Name | comment }}
+func Encode{{ .VarName }}Response(ctx context.Context, v interface{}) (interface{}, error) {
+	var hdr, trlr metadata.MD
+	resp, err := server.Encode{{ .VarName }}Response(ctx, v, &hdr, &trlr)
+	if err != nil {
+		return nil, err
+	}
+	if len(hdr) > 0 {
+		if err := grpc.SetHeader(ctx, hdr); err != nil {
+			return nil, err
+		}
+	}
+	if len(trlr) > 0 {
+		if err := grpc.SetTrailer(ctx, trlr); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+`
+
+// input: GRPCServiceData
+const grpcServerT = `{{ printf "Server implements the %s.%s interface using go-kit gRPC handlers." .PBPkgName .ServerInterface | comment }}
+type Server struct {
+{{- range .Methods }}
+	{{ .VarName }}H kitgrpc.Handler
+{{- end }}
+}
+
+{{ printf "NewServer returns a go-kit gRPC server for the %s service endpoints." .Service.Name | comment }}
+func NewServer(e *{{ .Service.PkgName }}.Endpoints, opts ...kitgrpc.ServerOption) *Server {
+	return &Server{
+{{- range .Methods }}
+		{{ .VarName }}H: kitgrpc.NewServer(
+			e.{{ .VarName }},
+	{{- if .Payload }}
+			Decode{{ .VarName }}Request,
+	{{- else }}
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil },
+	{{- end }}
+			Encode{{ .VarName }}Response,
+			opts...,
+		),
+{{- end }}
+	}
+}
+`
+
+// input: map[string]interface{}{"Service": GRPCServiceData, "Method": MethodData}
+const grpcServerMethodT = `{{ printf "%s implements the %q method in %s.%s interface." .Method.VarName .Method.VarName .Service.PBPkgName .Service.ServerInterface | comment }}
+func (s *Server) {{ .Method.VarName }}(ctx context.Context, message *{{ .Service.PBPkgName }}.{{ .Method.VarName }}Request) (*{{ .Service.PBPkgName }}.{{ .Method.VarName }}Response, error) {
+	_, resp, err := s.{{ .Method.VarName }}H.ServeGRPC(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*{{ .Service.PBPkgName }}.{{ .Method.VarName }}Response), nil
+}
+`
+
+const grpcResponseMetadataT = `type (
+	// headerKey is the context key used to store the response header
+	// metadata.
+	headerKey struct{}
+	// trailerKey is the context key used to store the response trailer
+	// metadata.
+	trailerKey struct{}
+)
+
+// SetResponseMetadata is a go-kit ClientResponseFunc that stores the response
+// header and trailer metadata in the context given to the response decoders.
+func SetResponseMetadata(ctx context.Context, hdr, trlr metadata.MD) context.Context {
+	ctx = context.WithValue(ctx, headerKey{}, hdr)
+	return context.WithValue(ctx, trailerKey{}, trlr)
+}
+
+// responseMetadata returns the response header and trailer metadata stored in
+// the given context by SetResponseMetadata.
+func responseMetadata(ctx context.Context) (hdr, trlr metadata.MD) {
+	hdr, _ = ctx.Value(headerKey{}).(metadata.MD)
+	trlr, _ = ctx.Value(trailerKey{}).(metadata.MD)
+	return
+}
+`
+
+// input: MethodData
+const grpcResponseDecoderT = `{{ printf "Decode%sResponse is a go-kit DecodeResponseFunc suitable for decoding %s responses. It wraps the goa generated decoder and provides it with the response metadata." .VarName .Name | comment }}
+func Decode{{ .VarName }}Response(ctx context.Context, v interface{}) (interface{}, error) {
+	hdr, trlr := responseMetadata(ctx)
+	return client.Decode{{ .VarName }}Response(ctx, v, hdr, trlr)
+}
+`
+
+// input: GRPCServiceData
+const grpcClientNewT = `{{ printf "New returns the %s service endpoints implemented with go-kit gRPC clients that make requests using the given connection." .Service.Name | comment }}
+func New(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) *{{ .Service.PkgName }}.Endpoints {
+	return &{{ .Service.PkgName }}.Endpoints{
+{{- range .Methods }}
+		{{ .VarName }}: New{{ .VarName }}Client(cc, opts...),
+{{- end }}
+	}
+}
+`
+
+// input: map[string]interface{}{"Service": GRPCServiceData, "Method": MethodData}
+const grpcClientT = `{{ printf "New%sClient returns an endpoint that makes gRPC requests to the %s service %s method using a go-kit gRPC client." .Method.VarName .Service.Service.Name .Method.Name | comment }}
+func New{{ .Method.VarName }}Client(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) endpoint.Endpoint {
+	return newClient(
+		cc,
+		{{ printf "%q" .Service.FullName }},
+		{{ printf "%q" .Method.VarName }},
+{{- if .Method.Payload }}
+		client.Encode{{ .Method.VarName }}Request,
+{{- else }}
+		func(context.Context, interface{}, *metadata.MD) (interface{}, error) {
+			return &{{ .Service.PBPkgName }}.{{ .Method.VarName }}Request{}, nil
+		},
+{{- end }}
+{{- if .Method.Result }}
+		Decode{{ .Method.VarName }}Response,
+{{- else }}
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil },
+{{- end }}
+		{{ .Service.PBPkgName }}.{{ .Method.VarName }}Response{},
+		opts...,
+	)
+}
+`
+
+const grpcClientHelperT = `// newClient returns an endpoint that encodes the request with the given goa
+// encoder and sends it with a go-kit gRPC client. The request metadata set by
+// the encoder is sent along with the request.
+func newClient(
+	cc *grpc.ClientConn,
+	svc, method string,
+	encode func(context.Context, interface{}, *metadata.MD) (interface{}, error),
+	dec kitgrpc.DecodeResponseFunc,
+	reply interface{},
+	opts ...kitgrpc.ClientOption,
+) endpoint.Endpoint {
+	opts = append(opts,
+		kitgrpc.ClientBefore(outgoingMetadata),
+		kitgrpc.ClientAfter(SetResponseMetadata),
+	)
+	e := kitgrpc.NewClient(
+		cc,
+		svc,
+		method,
+		func(_ context.Context, req interface{}) (interface{}, error) { return req, nil },
+		dec,
+		reply,
+		opts...,
+	).Endpoint()
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		var md metadata.MD
+		req, err := encode(ctx, v, &md)
+		if err != nil {
+			return nil, err
+		}
+		if len(md) > 0 {
+			if out, ok := metadata.FromOutgoingContext(ctx); ok {
+				md = metadata.Join(out, md)
+			}
+			ctx = metadata.NewOutgoingContext(ctx, md)
+		}
+		return e(ctx, req)
+	}
+}
+
+// outgoingMetadata is a go-kit ClientRequestFunc that adds the metadata of the
+// outgoing context to the request metadata.
+func outgoingMetadata(ctx context.Context, md *metadata.MD) context.Context {
+	if out, ok := metadata.FromOutgoingContext(ctx); ok {
+		*md = metadata.Join(*md, out)
+	}
+	return ctx
+}
+`
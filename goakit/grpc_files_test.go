@@ -0,0 +1,101 @@
+package goakit
+
+import (
+	"strings"
+	"testing"
+
+	grpccodegen "goa.design/goa/grpc/codegen"
+	grpcdesign "goa.design/goa/grpc/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestGRPCFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code map[string]map[string][]string
+	}{
+		"simple": {
+			DSL: testdata.GRPCSimpleDSL,
+			Code: map[string]map[string][]string{
+				"kitserver/encode_decode.go": {
+					"goakit-grpc-request-decoder":  []string{},
+					"goakit-grpc-response-encoder": []string{testdata.NoopGRPCResponseEncoderCode},
+				},
+				"kitserver/server.go": {
+					"goakit-grpc-server":        []string{testdata.SimpleGRPCServerCode},
+					"goakit-grpc-server-method": []string{testdata.NoopGRPCServerMethodCode},
+				},
+				"kitclient/encode_decode.go": {
+					"goakit-grpc-response-decoder": []string{},
+				},
+				"kitclient/client.go": {
+					"goakit-grpc-client-new": []string{testdata.SimpleGRPCClientNewCode},
+					"goakit-grpc-client":     []string{testdata.NoopGRPCClientCode},
+				},
+			},
+		},
+		"calc": {
+			DSL: testdata.GRPCCalcDSL,
+			Code: map[string]map[string][]string{
+				"kitserver/encode_decode.go": {
+					"goakit-grpc-request-decoder":  []string{testdata.AddGRPCRequestDecoderCode},
+					"goakit-grpc-response-encoder": []string{testdata.AddGRPCResponseEncoderCode},
+				},
+				"kitserver/server.go": {
+					"goakit-grpc-server":        []string{testdata.CalcGRPCServerCode},
+					"goakit-grpc-server-method": []string{testdata.AddGRPCServerMethodCode},
+				},
+				"kitclient/encode_decode.go": {
+					"goakit-grpc-response-decoder": []string{testdata.AddGRPCResponseDecoderCode},
+				},
+				"kitclient/client.go": {
+					"goakit-grpc-client-new": []string{testdata.CalcGRPCClientNewCode},
+					"goakit-grpc-client":     []string{testdata.AddGRPCClientCode},
+				},
+			},
+		},
+		"streaming": {
+			DSL: testdata.GRPCStreamingDSL,
+			Code: map[string]map[string][]string{
+				"kitserver/encode_decode.go": {
+					"goakit-grpc-request-decoder":  []string{testdata.UnaryGRPCRequestDecoderCode},
+					"goakit-grpc-response-encoder": []string{testdata.UnaryGRPCResponseEncoderCode},
+				},
+				"kitserver/server.go": {
+					"goakit-grpc-server":        []string{testdata.StreamingGRPCServerCode},
+					"goakit-grpc-server-method": []string{testdata.UnaryGRPCServerMethodCode},
+				},
+				"kitclient/encode_decode.go": {
+					"goakit-grpc-response-decoder": []string{},
+				},
+				"kitclient/client.go": {
+					"goakit-grpc-client-new": []string{testdata.StreamingGRPCClientNewCode},
+					"goakit-grpc-client":     []string{testdata.UnaryGRPCClientCode},
+				},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			grpccodegen.RunGRPCDSL(t, c.DSL)
+			fs := GRPCFiles("", grpcdesign.Root)
+			if len(fs) != len(c.Code) {
+				t.Fatalf("got %d files, expected %d", len(fs), len(c.Code))
+			}
+			for suffix, code := range c.Code {
+				var found bool
+				for _, f := range fs {
+					if strings.HasSuffix(f.Path, suffix) {
+						found = true
+						for sec, secCode := range code {
+							testCode(t, f, sec, secCode)
+						}
+					}
+				}
+				if !found {
+					t.Errorf("%s file not found", suffix)
+				}
+			}
+		})
+	}
+}
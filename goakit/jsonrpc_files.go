@@ -0,0 +1,197 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// JSONRPCFiles produces the files containing the go-kit JSON-RPC server and
+// client of each service. The JSON-RPC codecs reuse the goa generated HTTP
+// encoders and decoders so that the same design serves both REST and JSON-RPC
+// requests.
+func JSONRPCFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, 0, 2*len(root.HTTPServices))
+	for _, svc := range root.HTTPServices {
+		fw = append(fw, jsonrpcServerFile(genpkg, svc), jsonrpcClientFile(genpkg, svc))
+	}
+	return fw
+}
+
+// jsonrpcServerFile returns the file defining the go-kit JSON-RPC codecs and
+// server of the given service.
+func jsonrpcServerFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitjsonrpc", "server.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit JSON-RPC server", svc.Name())
+	fm := codegen.TemplateFuncs()
+	fm["queryParams"] = queryParams
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "jsonrpc", []*codegen.ImportSpec{
+			{Path: "net/http"},
+			{Path: "github.com/go-kit/kit/transport/http/jsonrpc"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: "goa.design/plugins/goakit/kitjsonrpc"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/server"},
+		}),
+		{
+			Name:   "goakit-jsonrpc-path",
+			Source: jsonrpcPathT,
+			Data:   data,
+		},
+		{
+			Name:   "goakit-jsonrpc-server",
+			Source: jsonrpcServerT,
+			Data:   data,
+		},
+		{
+			Name:    "goakit-jsonrpc-codec-map",
+			Source:  jsonrpcCodecMapT,
+			Data:    data,
+			FuncMap: fm,
+		},
+		{
+			Name:   "goakit-jsonrpc-mount",
+			Source: jsonrpcMountT,
+			Data:   data,
+		},
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// jsonrpcClientFile returns the file defining the go-kit JSON-RPC clients of
+// the given service.
+func jsonrpcClientFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitjsonrpc", "client.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit JSON-RPC client", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "jsonrpc", []*codegen.ImportSpec{
+			{Path: "net/http"},
+			{Path: "net/url"},
+			{Path: "github.com/go-kit/kit/transport/http/jsonrpc"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: "goa.design/plugins/goakit/kitjsonrpc"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/client"},
+		}),
+		{
+			Name:   "goakit-jsonrpc-client-new",
+			Source: jsonrpcClientNewT,
+			Data:   data,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-jsonrpc-client",
+			Source: jsonrpcClientT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// queryParams returns the Go code listing the names of the query parameters of
+// the given endpoint prefixed with a comma, the empty string if the endpoint
+// has no query parameter.
+func queryParams(e *httpcodegen.EndpointData) string {
+	if e.Payload.Request == nil {
+		return ""
+	}
+	var names []string
+	for _, p := range e.Payload.Request.QueryParams {
+		names = append(names, fmt.Sprintf("%q", p.Name))
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(names, ", ")
+}
+
+// input: ServiceData
+const jsonrpcPathT = `{{ printf "Path is the path of the route that serves the %s service JSON-RPC requests." .Service.Name | comment }}
+const Path = {{ printf "%q" (printf "/jsonrpc/%s" .Service.Name) }}
+`
+
+// input: ServiceData
+const jsonrpcServerT = `{{ printf "NewServer returns a go-kit JSON-RPC server that serves the %s service methods. The JSON-RPC method names are the method names defined in the design." .Service.Name | comment }}
+func NewServer(e *{{ .Service.PkgName }}.Endpoints, opts ...jsonrpc.ServerOption) *jsonrpc.Server {
+	return jsonrpc.NewServer(NewEndpointCodecMap(e), opts...)
+}
+`
+
+// input: ServiceData
+const jsonrpcCodecMapT = `{{ printf "NewEndpointCodecMap returns the go-kit JSON-RPC codecs of the %s service methods indexed by method name. The params are decoded with the goa generated HTTP request decoders so that they are validated like the HTTP requests and the results are encoded with the goa generated HTTP response encoders." .Service.Name | comment }}
+func NewEndpointCodecMap(e *{{ .Service.PkgName }}.Endpoints) jsonrpc.EndpointCodecMap {
+	return jsonrpc.EndpointCodecMap{
+	{{- range .Endpoints }}
+		{{ printf "%q" .Method.Name }}: jsonrpc.EndpointCodec{
+			Endpoint: e.{{ .Method.VarName }},
+		{{- if .Payload.Ref }}
+			Decode:   kitjsonrpc.DecodeParams(server.{{ .RequestDecoder }}, {{ printf "%q" (index .Routes 0).Path }}{{ queryParams . }}),
+		{{- else }}
+			Decode:   kitjsonrpc.DecodeNoParams,
+		{{- end }}
+			Encode:   kitjsonrpc.EncodeResult(server.{{ .ResponseEncoder }}),
+		},
+	{{- end }}
+	}
+}
+`
+
+// input: ServiceData
+const jsonrpcMountT = `{{ printf "Mount configures the mux to serve the %s service JSON-RPC requests made to Path." .Service.Name | comment }}
+func Mount(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("POST", Path, f)
+}
+`
+
+// input: ServiceData
+const jsonrpcClientNewT = `{{ printf "New returns the %s service endpoints implemented with go-kit JSON-RPC clients that make requests to the given host using the given scheme." .Service.Name | comment }}
+func New(scheme, host string, opts ...jsonrpc.ClientOption) *{{ .Service.PkgName }}.Endpoints {
+	u := &url.URL{Scheme: scheme, Host: host, Path: Path}
+	return &{{ .Service.PkgName }}.Endpoints{
+	{{- range .Endpoints }}
+		{{ .Method.VarName }}: New{{ .Method.VarName }}Client(u, opts...).Endpoint(),
+	{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const jsonrpcClientT = `{{ if or .Payload.Ref .Result -}}
+{{ printf "New%sClient returns a go-kit JSON-RPC client that calls the %s service %s method. The params and result are encoded and decoded with the goa generated HTTP client functions." .Method.VarName .ServiceName .Method.Name | comment }}
+{{- else -}}
+{{ printf "New%sClient returns a go-kit JSON-RPC client that calls the %s service %s method." .Method.VarName .ServiceName .Method.Name | comment }}
+{{- end }}
+func New{{ .Method.VarName }}Client(u *url.URL, opts ...jsonrpc.ClientOption) *jsonrpc.Client {
+{{- if .Payload.Ref }}
+	c := client.NewClient(u.Scheme, u.Host, nil, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+{{- end }}
+	return jsonrpc.NewClient(u, {{ printf "%q" .Method.Name }}, append([]jsonrpc.ClientOption{
+{{- if .Payload.Ref }}
+		jsonrpc.ClientRequestEncoder(kitjsonrpc.EncodeParams(c.Build{{ .Method.VarName }}Request, {{ if .RequestEncoder }}client.{{ .RequestEncoder }}(goahttp.RequestEncoder){{ else }}nil{{ end }}, {{ printf "%q" (index .Routes 0).Path }})),
+{{- else }}
+		jsonrpc.ClientRequestEncoder(kitjsonrpc.EncodeNoParams),
+{{- end }}
+{{- if .Result }}
+		jsonrpc.ClientResponseDecoder(kitjsonrpc.DecodeResult(client.{{ .ResponseDecoder }}, {{ (index .Result.Responses 0).StatusCode }})),
+{{- else }}
+		jsonrpc.ClientResponseDecoder(kitjsonrpc.DecodeNoResult),
+{{- end }}
+	}, opts...)...)
+}
+`
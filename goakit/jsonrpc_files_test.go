@@ -0,0 +1,23 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestJSONRPCFiles(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.JSONRPCDSL)
+	fs := JSONRPCFiles("", httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	testCode(t, fs[0], "goakit-jsonrpc-codec-map", []string{testdata.RPCServiceJSONRPCCodecMapCode})
+	testCode(t, fs[1], "goakit-jsonrpc-client-new", []string{testdata.RPCServiceJSONRPCClientNewCode})
+	testCode(t, fs[1], "goakit-jsonrpc-client", []string{
+		testdata.ParamsMethodJSONRPCClientCode,
+		testdata.NoPayloadMethodJSONRPCClientCode,
+	})
+}
@@ -0,0 +1,381 @@
+// Package kitauth provides the go-kit HTTP request functions and endpoint
+// middlewares used by the code that goakit generates to enforce the security
+// requirements defined with the security plugin DSL. The basic auth and JWT
+// schemes are enforced with the go-kit auth/basic and auth/jwt middlewares,
+// the API key schemes with the APIKey middleware and the OAuth2 schemes with
+// the OAuth2 middleware:
+//
+//	a := &calckitsvr.Authorizers{
+//		Basic:  basic.AuthMiddleware("user", "password", "calc"),
+//		JWT:    kitjwt.NewParser(keyFunc, stdjwt.SigningMethodHS256, kitjwt.MapClaimsFactory),
+//		APIKey: validateKey,
+//		OAuth2: validateToken,
+//	}
+//	endpoints.Apply(calckitsvr.SecurityMiddleware(a))
+//
+// The scopes required by the security requirements are checked against the
+// scopes returned by Scopes once the scheme middleware authorizes the
+// request.
+package kitauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+type (
+	// CredentialsFunc validates the credentials read from a request. It
+	// returns the context used to call the endpoint or an error if the
+	// credentials are invalid.
+	CredentialsFunc func(ctx context.Context, credentials string) (context.Context, error)
+
+	// Error is the error returned by the middlewares when a request fails
+	// authorization. It implements the go-kit kithttp.StatusCoder and
+	// kithttp.Headerer interfaces so that kithttp.DefaultErrorEncoder
+	// writes a 401 Unauthorized response unless the wrapped error defines
+	// another status code.
+	Error struct {
+		// Scheme is the name of the security scheme as defined in the
+		// design.
+		Scheme string
+		// Err is the error returned by the scheme middleware.
+		Err error
+	}
+
+	// ScopesError is the error wrapped in Error when the request is
+	// authorized by a scheme but lacks some of the scopes required by the
+	// security requirement. kithttp.DefaultErrorEncoder writes a 403
+	// Forbidden response.
+	ScopesError struct {
+		// Missing lists the required scopes missing from the request.
+		Missing []string
+	}
+
+	// credentialsKey is the type of the context keys used to store the API
+	// keys and OAuth2 access tokens read from the requests, the keys are the
+	// scheme names.
+	credentialsKey string
+
+	// scopesKeyType is the type of the context key used to store the
+	// scopes set with WithScopes.
+	scopesKeyType struct{}
+)
+
+var (
+	// ErrNoAuthorizer is the error wrapped in Error when no middleware
+	// authorizes the requests secured with a scheme.
+	ErrNoAuthorizer = errors.New("no authorizer")
+	// ErrMissingCredentials is the error wrapped in Error when the request
+	// does not hold the credentials of a scheme.
+	ErrMissingCredentials = errors.New("missing credentials")
+)
+
+// Error returns the name of the scheme and the message of the wrapped error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Scheme, e.Err)
+}
+
+// StatusCode returns the status code of the wrapped error if it defines one,
+// 401 Unauthorized otherwise.
+func (e *Error) StatusCode() int {
+	if sc, ok := e.Err.(kithttp.StatusCoder); ok {
+		return sc.StatusCode()
+	}
+	return http.StatusUnauthorized
+}
+
+// Headers returns the headers of the wrapped error if it defines any, such as
+// the WWW-Authenticate header of the go-kit basic auth errors.
+func (e *Error) Headers() http.Header {
+	if h, ok := e.Err.(kithttp.Headerer); ok {
+		return h.Headers()
+	}
+	return nil
+}
+
+// Error returns the list of missing scopes.
+func (e *ScopesError) Error() string {
+	return "missing scopes: " + strings.Join(e.Missing, ", ")
+}
+
+// StatusCode returns 403 Forbidden.
+func (e *ScopesError) StatusCode() int {
+	return http.StatusForbidden
+}
+
+// JWTToContext returns a go-kit HTTP request function that stores the token
+// read from the given header or query string parameter of the request in the
+// context under the kitjwt.JWTTokenContextKey key used by kitjwt.NewParser.
+// The "Bearer" prefix is removed from the Authorization header value.
+func JWTToContext(in, key string) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		token := credentials(r, in, key)
+		if token == "" {
+			return ctx
+		}
+		return context.WithValue(ctx, kitjwt.JWTTokenContextKey, token)
+	}
+}
+
+// JWTToHTTP returns a go-kit HTTP request function that writes the token held
+// by the context under the kitjwt.JWTTokenContextKey key, for example by the
+// kitjwt.NewSigner middleware, in the given header or query string parameter
+// of the request.
+func JWTToHTTP(in, key string) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		if token, ok := ctx.Value(kitjwt.JWTTokenContextKey).(string); ok {
+			setCredentials(r, in, key, token)
+		}
+		return ctx
+	}
+}
+
+// APIKeyToContext returns a go-kit HTTP request function that stores the API
+// key read from the given header or query string parameter of the request in
+// the context so that the APIKey middleware of the given scheme validates it.
+func APIKeyToContext(scheme, in, key string) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		k := credentials(r, in, key)
+		if k == "" {
+			return ctx
+		}
+		return context.WithValue(ctx, credentialsKey(scheme), k)
+	}
+}
+
+// APIKeyToHTTP returns a go-kit HTTP request function that writes the given
+// API key in the given header or query string parameter of the request.
+func APIKeyToHTTP(in, key, apiKey string) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		setCredentials(r, in, key, apiKey)
+		return ctx
+	}
+}
+
+// OAuth2ToContext returns a go-kit HTTP request function that stores the
+// access token read from the Authorization header of the request in the
+// context so that the OAuth2 middleware of the given scheme validates it.
+func OAuth2ToContext(scheme string) kithttp.RequestFunc {
+	return APIKeyToContext(scheme, "header", "Authorization")
+}
+
+// OAuth2ToHTTP returns a go-kit HTTP request function that writes the given
+// access token in the Authorization header of the request.
+func OAuth2ToHTTP(token string) kithttp.RequestFunc {
+	return APIKeyToHTTP("header", "Authorization", token)
+}
+
+// BasicAuthToHTTP returns a go-kit HTTP request function that sets the basic
+// auth credentials of the request.
+func BasicAuthToHTTP(user, pass string) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		r.SetBasicAuth(user, pass)
+		return ctx
+	}
+}
+
+// APIKey returns a middleware that validates the API key stored in the context
+// by the APIKeyToContext request function of the given scheme with fn. APIKey
+// returns nil if fn is nil.
+func APIKey(scheme string, fn CredentialsFunc) endpoint.Middleware {
+	if fn == nil {
+		return nil
+	}
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			key, ok := ctx.Value(credentialsKey(scheme)).(string)
+			if !ok {
+				return nil, ErrMissingCredentials
+			}
+			ctx, err := fn(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// OAuth2 returns a middleware that validates the access token stored in the
+// context by the OAuth2ToContext request function of the given scheme with fn.
+// fn may store the scopes granted to the token with WithScopes. OAuth2 returns
+// nil if fn is nil.
+func OAuth2(scheme string, fn CredentialsFunc) endpoint.Middleware {
+	return APIKey(scheme, fn)
+}
+
+// WithScopes returns a copy of ctx that holds the given scopes. The
+// CredentialsFunc functions use it to record the scopes granted to the
+// credentials they validate.
+func WithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesKeyType{}, scopes)
+}
+
+// Scopes returns the scopes granted to the request credentials. These are the
+// scopes stored with WithScopes if any, the scopes listed by the claims that
+// kitjwt.NewParser stores in the context otherwise. The claims list the scopes
+// if they implement a Scopes() []string method or if they are kitjwt map
+// claims with a space separated "scope" string or a "scopes" array.
+func Scopes(ctx context.Context) []string {
+	if scopes, ok := ctx.Value(scopesKeyType{}).([]string); ok {
+		return scopes
+	}
+	switch c := ctx.Value(kitjwt.JWTClaimsContextKey).(type) {
+	case interface{ Scopes() []string }:
+		return c.Scopes()
+	case jwt.MapClaims:
+		if scope, ok := c["scope"].(string); ok {
+			return strings.Fields(scope)
+		}
+		if scopes, ok := c["scopes"].([]interface{}); ok {
+			var res []string
+			for _, s := range scopes {
+				if str, ok := s.(string); ok {
+					res = append(res, str)
+				}
+			}
+			return res
+		}
+	}
+	return nil
+}
+
+// Scheme returns a middleware that authorizes the requests secured with the
+// given scheme using m and checks that the credentials are granted the given
+// scopes. The errors returned by m are wrapped in Error, the middleware fails
+// with ErrNoAuthorizer if m is nil and with a ScopesError if some of the
+// scopes are missing from the scopes returned by Scopes.
+func Scheme(name string, m endpoint.Middleware, scopes ...string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if m == nil {
+				return nil, &Error{Scheme: name, Err: ErrNoAuthorizer}
+			}
+			actx, err := authorize(m, ctx, req)
+			if err != nil {
+				if _, ok := err.(*Error); !ok {
+					err = &Error{Scheme: name, Err: err}
+				}
+				return nil, err
+			}
+			if missing := missingScopes(Scopes(actx), scopes); len(missing) > 0 {
+				return nil, &Error{Scheme: name, Err: &ScopesError{Missing: missing}}
+			}
+			return next(actx, req)
+		}
+	}
+}
+
+// All returns a middleware that calls the endpoint if all the given
+// middlewares authorize the request. The context returned by each middleware
+// is given to the next.
+func All(ms ...endpoint.Middleware) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			for _, m := range ms {
+				actx, err := authorize(m, ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				ctx = actx
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// Any returns a middleware that calls the endpoint if any of the given
+// middlewares authorizes the request. The middlewares are tried in order, the
+// error returned by the first middleware is returned if none succeed.
+func Any(ms ...endpoint.Middleware) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			var first error
+			for _, m := range ms {
+				actx, err := authorize(m, ctx, req)
+				if err == nil {
+					return next(actx, req)
+				}
+				if first == nil {
+					first = err
+				}
+			}
+			if first != nil {
+				return nil, first
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// authorize runs m with an endpoint that records the context it is called
+// with. It returns that context or the error returned by m.
+func authorize(m endpoint.Middleware, ctx context.Context, req interface{}) (context.Context, error) {
+	actx := ctx
+	_, err := m(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		actx = ctx
+		return nil, nil
+	})(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return actx, nil
+}
+
+// missingScopes returns the scopes listed in required that are not listed in
+// granted.
+func missingScopes(granted, required []string) []string {
+	var missing []string
+	for _, r := range required {
+		found := false
+		for _, g := range granted {
+			if g == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// credentials returns the value of the given header or query string
+// parameter. The bearer scheme prefix is removed from the Authorization
+// header value.
+func credentials(r *http.Request, in, key string) string {
+	if in == "query" {
+		return r.URL.Query().Get(key)
+	}
+	val := r.Header.Get(key)
+	if strings.EqualFold(key, "Authorization") {
+		if len(val) > 7 && strings.EqualFold(val[:7], "Bearer ") {
+			return val[7:]
+		}
+	}
+	return val
+}
+
+// setCredentials writes val in the given header or query string parameter.
+// The bearer scheme prefix is added to the Authorization header value.
+func setCredentials(r *http.Request, in, key, val string) {
+	if in == "query" {
+		q := r.URL.Query()
+		q.Set(key, val)
+		r.URL.RawQuery = q.Encode()
+		return
+	}
+	if strings.EqualFold(key, "Authorization") {
+		val = "Bearer " + val
+	}
+	r.Header.Set(key, val)
+}
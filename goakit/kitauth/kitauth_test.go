@@ -0,0 +1,268 @@
+package kitauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/go-kit/kit/auth/basic"
+	kitjwt "github.com/go-kit/kit/auth/jwt"
+	"github.com/go-kit/kit/endpoint"
+	kithttp "github.com/go-kit/kit/transport/http"
+)
+
+type ctxKey int
+
+const userKey ctxKey = iota + 1
+
+// validKey returns a CredentialsFunc that accepts the given key and stores it
+// in the context.
+func validKey(key string) CredentialsFunc {
+	return func(ctx context.Context, k string) (context.Context, error) {
+		if k != key {
+			return nil, errors.New("invalid key")
+		}
+		return context.WithValue(ctx, userKey, k), nil
+	}
+}
+
+// user returns the value stored in the context by validKey.
+func user(ctx context.Context, _ interface{}) (interface{}, error) {
+	u, _ := ctx.Value(userKey).(string)
+	return u, nil
+}
+
+// call runs the request through the request functions and the endpoint
+// wrapped with m.
+func call(r *http.Request, m endpoint.Middleware, before ...kithttp.RequestFunc) (interface{}, error) {
+	ctx := context.Background()
+	for _, f := range before {
+		ctx = f(ctx, r)
+	}
+	return m(user)(ctx, nil)
+}
+
+func TestAPIKey(t *testing.T) {
+	cases := map[string]struct {
+		In, Key, Value string
+		Fn             CredentialsFunc
+		Expected       string
+		Err            error
+	}{
+		"header":      {"header", "X-API-Key", "secret", validKey("secret"), "secret", nil},
+		"query":       {"query", "key", "secret", validKey("secret"), "secret", nil},
+		"missing":     {"header", "X-API-Key", "", validKey("secret"), "", ErrMissingCredentials},
+		"invalid":     {"header", "X-API-Key", "bad", validKey("secret"), "", nil},
+		"no-function": {"header", "X-API-Key", "secret", nil, "", ErrNoAuthorizer},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if c.Value != "" {
+				APIKeyToHTTP(c.In, c.Key, c.Value)(context.Background(), r)
+			}
+			res, err := call(r, Scheme("api_key", APIKey("api_key", c.Fn)), APIKeyToContext("api_key", c.In, c.Key))
+			if c.Expected != "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if res != c.Expected {
+					t.Errorf("got %v, expected %q", res, c.Expected)
+				}
+				return
+			}
+			e, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("got error %v, expected an *Error", err)
+			}
+			if e.Scheme != "api_key" {
+				t.Errorf("got scheme %q, expected %q", e.Scheme, "api_key")
+			}
+			if c.Err != nil && e.Err != c.Err {
+				t.Errorf("got error %v, expected %v", e.Err, c.Err)
+			}
+			if e.StatusCode() != http.StatusUnauthorized {
+				t.Errorf("got status %d, expected %d", e.StatusCode(), http.StatusUnauthorized)
+			}
+		})
+	}
+}
+
+func TestBasicAuth(t *testing.T) {
+	m := Scheme("basic", basic.AuthMiddleware("user", "pass", "realm"))
+	r := httptest.NewRequest("GET", "/", nil)
+	BasicAuthToHTTP("user", "pass")(context.Background(), r)
+	if _, err := call(r, m, kithttp.PopulateRequestContext); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	r = httptest.NewRequest("GET", "/", nil)
+	BasicAuthToHTTP("user", "bad")(context.Background(), r)
+	_, err := call(r, m, kithttp.PopulateRequestContext)
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("got error %v, expected an *Error", err)
+	}
+	if len(e.Headers()["WWW-Authenticate"]) == 0 {
+		t.Error("expected the WWW-Authenticate header of the go-kit basic auth error")
+	}
+}
+
+func TestJWT(t *testing.T) {
+	cases := map[string]struct{ In, Key string }{
+		"authorization": {"header", "Authorization"},
+		"header":        {"header", "X-Token"},
+		"query":         {"query", "token"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			ctx := context.WithValue(context.Background(), kitjwt.JWTTokenContextKey, "token")
+			JWTToHTTP(c.In, c.Key)(ctx, r)
+			ctx = JWTToContext(c.In, c.Key)(context.Background(), r)
+			if token := ctx.Value(kitjwt.JWTTokenContextKey); token != "token" {
+				t.Errorf("got token %v, expected %q", token, "token")
+			}
+		})
+	}
+}
+
+func TestOAuth2(t *testing.T) {
+	m := Scheme("oauth2", OAuth2("oauth2", validKey("token")))
+	r := httptest.NewRequest("GET", "/", nil)
+	OAuth2ToHTTP("token")(context.Background(), r)
+	res, err := call(r, m, OAuth2ToContext("oauth2"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != "token" {
+		t.Errorf("got %v, expected %q", res, "token")
+	}
+}
+
+// scopedClaims are JWT claims that list their scopes.
+type scopedClaims struct {
+	jwt.StandardClaims
+	scopes []string
+}
+
+func (c *scopedClaims) Scopes() []string { return c.scopes }
+
+func TestScopes(t *testing.T) {
+	claims := func(c jwt.Claims) context.Context {
+		return context.WithValue(context.Background(), kitjwt.JWTClaimsContextKey, c)
+	}
+	cases := map[string]struct {
+		Ctx      context.Context
+		Expected []string
+	}{
+		"with-scopes":     {WithScopes(context.Background(), "a", "b"), []string{"a", "b"}},
+		"map-scope":       {claims(jwt.MapClaims{"scope": "a b"}), []string{"a", "b"}},
+		"map-scopes":      {claims(jwt.MapClaims{"scopes": []interface{}{"a", "b"}}), []string{"a", "b"}},
+		"scopes-method":   {claims(&scopedClaims{scopes: []string{"a"}}), []string{"a"}},
+		"standard-claims": {claims(&jwt.StandardClaims{}), nil},
+		"none":            {context.Background(), nil},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if scopes := Scopes(c.Ctx); !reflect.DeepEqual(scopes, c.Expected) {
+				t.Errorf("got scopes %v, expected %v", scopes, c.Expected)
+			}
+		})
+	}
+}
+
+func TestSchemeScopes(t *testing.T) {
+	grant := func(scopes ...string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				return next(WithScopes(ctx, scopes...), req)
+			}
+		}
+	}
+	cases := map[string]struct {
+		Granted, Required, Missing []string
+	}{
+		"granted":  {[]string{"api:read", "api:write"}, []string{"api:write"}, nil},
+		"missing":  {[]string{"api:read"}, []string{"api:read", "api:write"}, []string{"api:write"}},
+		"none":     {nil, []string{"api:read"}, []string{"api:read"}},
+		"no-scope": {nil, nil, nil},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := call(httptest.NewRequest("GET", "/", nil), Scheme("token", grant(c.Granted...), c.Required...))
+			if c.Missing == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			e, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("got error %v, expected an *Error", err)
+			}
+			serr, ok := e.Err.(*ScopesError)
+			if !ok {
+				t.Fatalf("got error %v, expected a *ScopesError", e.Err)
+			}
+			if !reflect.DeepEqual(serr.Missing, c.Missing) {
+				t.Errorf("got missing scopes %v, expected %v", serr.Missing, c.Missing)
+			}
+			if e.StatusCode() != http.StatusForbidden {
+				t.Errorf("got status %d, expected %d", e.StatusCode(), http.StatusForbidden)
+			}
+		})
+	}
+}
+
+func TestAnyAll(t *testing.T) {
+	var (
+		deny  = Scheme("deny", nil)
+		allow = Scheme("allow", func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				return next(context.WithValue(ctx, userKey, "allowed"), req)
+			}
+		})
+	)
+	cases := map[string]struct {
+		Middleware endpoint.Middleware
+		Expected   string
+		Scheme     string
+	}{
+		"any-allow": {Any(All(deny), All(allow)), "allowed", ""},
+		"any-deny":  {Any(All(deny), All(deny, allow)), "", "deny"},
+		"all-allow": {Any(All(allow, allow)), "allowed", ""},
+		"all-deny":  {Any(All(allow, deny)), "", "deny"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res, err := call(httptest.NewRequest("GET", "/", nil), c.Middleware)
+			if c.Scheme != "" {
+				e, ok := err.(*Error)
+				if !ok || e.Scheme != c.Scheme {
+					t.Fatalf("got error %v, expected a %q scheme error", err, c.Scheme)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if res != c.Expected {
+				t.Errorf("got %v, expected %q", res, c.Expected)
+			}
+		})
+	}
+}
+
+func TestEndpointError(t *testing.T) {
+	fail := errors.New("endpoint error")
+	e := Any(All(Scheme("allow", endpoint.Chain(func(next endpoint.Endpoint) endpoint.Endpoint { return next }))))(
+		func(context.Context, interface{}) (interface{}, error) { return nil, fail },
+	)
+	if _, err := e(context.Background(), nil); err != fail {
+		t.Errorf("got error %v, expected the endpoint error", err)
+	}
+}
@@ -0,0 +1,40 @@
+package kitendpoint
+
+import (
+	"github.com/go-kit/kit/endpoint"
+	goa "goa.design/goa"
+)
+
+// GoaMiddleware is a goa endpoint middleware. The UseGoa methods generated by
+// goakit accept a GoaMiddleware rather than a func(goa.Endpoint) goa.Endpoint
+// because goakit replaces the references to goa.Endpoint made by the generated
+// code with references to the go-kit endpoint type.
+type GoaMiddleware func(goa.Endpoint) goa.Endpoint
+
+// FromGoaEndpoint returns the go-kit endpoint that calls the goa endpoint e.
+func FromGoaEndpoint(e goa.Endpoint) endpoint.Endpoint {
+	return endpoint.Endpoint(e)
+}
+
+// ToGoaEndpoint returns the goa endpoint that calls the go-kit endpoint e.
+func ToGoaEndpoint(e endpoint.Endpoint) goa.Endpoint {
+	return goa.Endpoint(e)
+}
+
+// FromGoaMiddleware returns a go-kit middleware that applies the goa endpoint
+// middleware m. It makes it possible to apply the goa endpoint middlewares to
+// the endpoints of the services generated with goakit.
+func FromGoaMiddleware(m func(goa.Endpoint) goa.Endpoint) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return FromGoaEndpoint(m(ToGoaEndpoint(next)))
+	}
+}
+
+// ToGoaMiddleware returns a goa endpoint middleware that applies the go-kit
+// middleware m. It makes it possible to apply the go-kit middlewares to the
+// endpoints of the services generated without goakit.
+func ToGoaMiddleware(m endpoint.Middleware) func(goa.Endpoint) goa.Endpoint {
+	return func(next goa.Endpoint) goa.Endpoint {
+		return ToGoaEndpoint(m(FromGoaEndpoint(next)))
+	}
+}
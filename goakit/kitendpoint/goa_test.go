@@ -0,0 +1,54 @@
+package kitendpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/kit/endpoint"
+	goa "goa.design/goa"
+)
+
+// suffix returns a go-kit middleware that appends s to the string responses.
+func suffix(s string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			res, err := next(ctx, req)
+			return res.(string) + s, err
+		}
+	}
+}
+
+// goaSuffix returns a goa endpoint middleware that appends s to the string
+// responses.
+func goaSuffix(s string) func(goa.Endpoint) goa.Endpoint {
+	return func(next goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			res, err := next(ctx, req)
+			return res.(string) + s, err
+		}
+	}
+}
+
+func echo(_ context.Context, req interface{}) (interface{}, error) {
+	return req, nil
+}
+
+func TestEndpointConverters(t *testing.T) {
+	if res, _ := FromGoaEndpoint(goa.Endpoint(echo))(context.Background(), "a"); res != "a" {
+		t.Errorf("got go-kit endpoint response %v, expected %q", res, "a")
+	}
+	if res, _ := ToGoaEndpoint(endpoint.Endpoint(echo))(context.Background(), "a"); res != "a" {
+		t.Errorf("got goa endpoint response %v, expected %q", res, "a")
+	}
+}
+
+func TestMiddlewareConverters(t *testing.T) {
+	e := FromGoaMiddleware(goaSuffix("-goa"))(echo)
+	if res, _ := e(context.Background(), "a"); res != "a-goa" {
+		t.Errorf("got go-kit middleware response %v, expected %q", res, "a-goa")
+	}
+	g := ToGoaMiddleware(suffix("-kit"))(echo)
+	if res, _ := g(context.Background(), "a"); res != "a-kit" {
+		t.Errorf("got goa middleware response %v, expected %q", res, "a-kit")
+	}
+}
@@ -0,0 +1,93 @@
+// Package kitendpoint provides go-kit endpoint middlewares labeled with the
+// service and method names defined in the design. The functions return
+// middleware factories suitable for the Apply method that goakit generates on
+// the service Endpoints struct:
+//
+//	endpoints := calcsvc.NewEndpoints(svc)
+//	endpoints.Apply(kitendpoint.Logging(logger, calcsvc.ServiceName))
+//
+// The package also converts goa endpoints and endpoint middlewares to and from
+// their go-kit counterparts.
+package kitendpoint
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	"github.com/opentracing/opentracing-go"
+)
+
+// Logging returns a function that builds, for each method of the given
+// service, a middleware that logs the service and method names, the duration
+// and the error (if any) of the requests.
+func Logging(logger log.Logger, service string) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, req interface{}) (res interface{}, err error) {
+				defer func(begin time.Time) {
+					logger.Log("service", service, "method", method, "took", time.Since(begin), "err", err)
+				}(time.Now())
+				return next(ctx, req)
+			}
+		}
+	}
+}
+
+// Instrumenting returns a function that builds, for each method of the given
+// service, a middleware that records the duration of the requests in seconds
+// with the given histogram and counts them with the given counter. Both
+// metrics are labeled with "service", "method" and "error", the value
+// returned by ErrorName for the error returned by the endpoint.
+func Instrumenting(duration metrics.Histogram, requests metrics.Counter, service string) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		return func(next endpoint.Endpoint) endpoint.Endpoint {
+			return func(ctx context.Context, req interface{}) (res interface{}, err error) {
+				defer func(begin time.Time) {
+					lvs := []string{"service", service, "method", method, "error", ErrorName(err)}
+					duration.With(lvs...).Observe(time.Since(begin).Seconds())
+					requests.With(lvs...).Add(1)
+				}(time.Now())
+				return next(ctx, req)
+			}
+		}
+	}
+}
+
+// ErrorName returns the name of err as defined in the design, "unknown" if
+// err is not one of the errors defined in the design or the empty string if
+// err is nil.
+func ErrorName(err error) string {
+	if err == nil {
+		return ""
+	}
+	if en, ok := err.(interface{ ErrorName() string }); ok {
+		return en.ErrorName()
+	}
+	return "unknown"
+}
+
+// Tracing returns a function that builds, for each method of the given
+// service, a middleware that traces the requests with a server span named
+// "service.method". The middleware finishes the span started from the request
+// headers by the generated TraceServerBefore server options or starts a new
+// root span if there is none.
+func Tracing(tracer opentracing.Tracer, service string) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		return kitot.TraceServer(tracer, service+"."+method)
+	}
+}
+
+// ClientTracing returns a function that builds, for each method of the given
+// service, a middleware that traces the requests with a client span named
+// "service.method". The span is a child of the span held by the request
+// context if any. The generated TraceClientBefore client option injects the
+// span context in the request headers.
+func ClientTracing(tracer opentracing.Tracer, service string) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		return kitot.TraceClient(tracer, service+"."+method)
+	}
+}
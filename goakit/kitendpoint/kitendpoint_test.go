@@ -0,0 +1,157 @@
+package kitendpoint
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+type recordingHistogram struct {
+	labels []string
+	values []float64
+}
+
+func (h *recordingHistogram) With(lvs ...string) metrics.Histogram {
+	h.labels = lvs
+	return h
+}
+
+func (h *recordingHistogram) Observe(value float64) {
+	h.values = append(h.values, value)
+}
+
+type recordingCounter struct {
+	labels []string
+	deltas []float64
+}
+
+func (c *recordingCounter) With(lvs ...string) metrics.Counter {
+	c.labels = lvs
+	return c
+}
+
+func (c *recordingCounter) Add(delta float64) {
+	c.deltas = append(c.deltas, delta)
+}
+
+// namedError is an error defined in the design.
+type namedError struct {
+	name string
+}
+
+func (e *namedError) Error() string     { return e.name }
+func (e *namedError) ErrorName() string { return e.name }
+
+var errBoom = errors.New("boom")
+
+func TestLogging(t *testing.T) {
+	cases := map[string]error{
+		"success": nil,
+		"error":   errBoom,
+	}
+	for name, err := range cases {
+		t.Run(name, func(t *testing.T) {
+			var keyvals []interface{}
+			logger := log.LoggerFunc(func(kv ...interface{}) error {
+				keyvals = kv
+				return nil
+			})
+			e := Logging(logger, "calc")("add")(newEndpoint(err))
+			e(context.Background(), nil)
+			if len(keyvals) != 8 {
+				t.Fatalf("got %d log values, expected 8", len(keyvals))
+			}
+			if keyvals[1] != "calc" {
+				t.Errorf("got service %v, expected %q", keyvals[1], "calc")
+			}
+			if keyvals[3] != "add" {
+				t.Errorf("got method %v, expected %q", keyvals[3], "add")
+			}
+			if keyvals[7] != err {
+				t.Errorf("got error %v, expected %v", keyvals[7], err)
+			}
+		})
+	}
+}
+
+func TestInstrumenting(t *testing.T) {
+	cases := map[string]struct {
+		Err   error
+		Error string
+	}{
+		"success":        {nil, ""},
+		"error":          {errBoom, "unknown"},
+		"designed-error": {&namedError{"bad_request"}, "bad_request"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				duration = &recordingHistogram{}
+				requests = &recordingCounter{}
+			)
+			e := Instrumenting(duration, requests, "calc")("add")(newEndpoint(c.Err))
+			e(context.Background(), nil)
+			expected := []string{"service", "calc", "method", "add", "error", c.Error}
+			if !reflect.DeepEqual(duration.labels, expected) {
+				t.Errorf("got histogram labels %v, expected %v", duration.labels, expected)
+			}
+			if len(duration.values) != 1 {
+				t.Errorf("got %d observations, expected 1", len(duration.values))
+			}
+			if !reflect.DeepEqual(requests.labels, expected) {
+				t.Errorf("got counter labels %v, expected %v", requests.labels, expected)
+			}
+			if !reflect.DeepEqual(requests.deltas, []float64{1}) {
+				t.Errorf("got counter deltas %v, expected [1]", requests.deltas)
+			}
+		})
+	}
+}
+
+func TestTracing(t *testing.T) {
+	tracer := mocktracer.New()
+	e := Tracing(tracer, "calc")("add")(newEndpoint(nil))
+	e(context.Background(), nil)
+	spans := tracer.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	if spans[0].OperationName != "calc.add" {
+		t.Errorf("got span %q, expected %q", spans[0].OperationName, "calc.add")
+	}
+	if kind := spans[0].Tag(string(ext.SpanKind)); kind != ext.SpanKindRPCServerEnum {
+		t.Errorf("got span kind %v, expected %v", kind, ext.SpanKindRPCServerEnum)
+	}
+}
+
+func TestClientTracing(t *testing.T) {
+	tracer := mocktracer.New()
+	parent := tracer.StartSpan("parent")
+	ctx := opentracing.ContextWithSpan(context.Background(), parent)
+	e := ClientTracing(tracer, "calc")("add")(newEndpoint(nil))
+	e(ctx, nil)
+	spans := tracer.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, expected 1", len(spans))
+	}
+	if spans[0].OperationName != "calc.add" {
+		t.Errorf("got span %q, expected %q", spans[0].OperationName, "calc.add")
+	}
+	if spans[0].ParentID != parent.(*mocktracer.MockSpan).SpanContext.SpanID {
+		t.Errorf("got parent span %d, expected %d", spans[0].ParentID, parent.(*mocktracer.MockSpan).SpanContext.SpanID)
+	}
+}
+
+func newEndpoint(err error) endpoint.Endpoint {
+	return func(context.Context, interface{}) (interface{}, error) {
+		return nil, err
+	}
+}
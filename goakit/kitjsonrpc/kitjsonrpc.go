@@ -0,0 +1,312 @@
+// Package kitjsonrpc makes it possible to serve and call goa methods over the
+// go-kit JSON-RPC transport using the goa generated HTTP request decoders,
+// response encoders, request builders and response decoders. The JSON-RPC
+// params of a method are a JSON object that holds the fields of the HTTP
+// request body together with the path and query parameters of the method
+// HTTP route. The result of a method is the HTTP response body.
+//
+// The functions are used by the code that goakit generates in the kitjsonrpc
+// package of each service.
+package kitjsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	goahttp "goa.design/goa/http"
+)
+
+type (
+	// RequestDecoder is the type of the goa generated HTTP server request
+	// decoder constructors.
+	RequestDecoder func(goahttp.Muxer, func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error)
+
+	// ResponseEncoder is the type of the goa generated HTTP server response
+	// encoder constructors.
+	ResponseEncoder func(func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error
+
+	// RequestBuilder is the type of the goa generated HTTP client request
+	// builders.
+	RequestBuilder func(context.Context, interface{}) (*http.Request, error)
+
+	// RequestEncoder is the type of the goa generated HTTP client request
+	// encoders.
+	RequestEncoder func(*http.Request, interface{}) error
+
+	// ResponseDecoder is the type of the goa generated HTTP client response
+	// decoder constructors.
+	ResponseDecoder func(func(*http.Response) goahttp.Decoder, bool) func(*http.Response) (interface{}, error)
+
+	// varsMuxer is a muxer that returns the path parameters decoded from the
+	// JSON-RPC params.
+	varsMuxer struct {
+		*http.ServeMux
+		vars map[string]string
+	}
+)
+
+// DecodeParams returns a go-kit JSON-RPC decoder that decodes the params with
+// the given goa request decoder. pattern is the path of the method HTTP route,
+// its wildcards name the path parameters. query lists the names of the query
+// parameters. The decoding errors are returned as JSON-RPC invalid params
+// errors.
+func DecodeParams(dec RequestDecoder, pattern string, query ...string) jsonrpc.DecodeRequestFunc {
+	names := pathParams(pattern)
+	return func(ctx context.Context, msg json.RawMessage) (interface{}, error) {
+		var (
+			fields map[string]json.RawMessage
+			vars   = make(map[string]string)
+			values = make(url.Values)
+		)
+		if len(names) > 0 || len(query) > 0 {
+			if err := json.Unmarshal(msg, &fields); err != nil {
+				return nil, invalidParams(err)
+			}
+		}
+		for _, n := range names {
+			if raw, ok := fields[n]; ok {
+				vs, err := paramValues(raw)
+				if err != nil || len(vs) != 1 {
+					return nil, invalidParams(fmt.Errorf("invalid path parameter %q", n))
+				}
+				vars[n] = vs[0]
+			}
+		}
+		for _, n := range query {
+			if raw, ok := fields[n]; ok {
+				vs, err := paramValues(raw)
+				if err != nil {
+					return nil, invalidParams(fmt.Errorf("invalid query parameter %q", n))
+				}
+				values[n] = vs
+			}
+		}
+		var body []byte
+		if len(msg) > 0 && string(msg) != "null" {
+			body = msg
+		}
+		r, err := http.NewRequest("POST", "/", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		r = r.WithContext(ctx)
+		r.Header.Set("Content-Type", "application/json")
+		r.URL.RawQuery = values.Encode()
+		v, err := dec(&varsMuxer{http.NewServeMux(), vars}, goahttp.RequestDecoder)(r)
+		if err != nil {
+			return nil, invalidParams(err)
+		}
+		return v, nil
+	}
+}
+
+// DecodeNoParams is the go-kit JSON-RPC decoder of the methods that have no
+// payload.
+func DecodeNoParams(context.Context, json.RawMessage) (interface{}, error) {
+	return nil, nil
+}
+
+// EncodeResult returns a go-kit JSON-RPC encoder that encodes the results with
+// the given goa response encoder. The JSON-RPC result is the HTTP response
+// body, null if the body is empty.
+func EncodeResult(enc ResponseEncoder) jsonrpc.EncodeResponseFunc {
+	return func(ctx context.Context, v interface{}) (json.RawMessage, error) {
+		w := httptest.NewRecorder()
+		if err := enc(goahttp.ResponseEncoder)(ctx, w, v); err != nil {
+			return nil, err
+		}
+		if w.Body.Len() == 0 {
+			return nil, nil
+		}
+		return json.RawMessage(bytes.TrimSpace(w.Body.Bytes())), nil
+	}
+}
+
+// EncodeParams returns a go-kit JSON-RPC encoder that encodes the params with
+// the given goa request builder and encoder. enc is nil if the method HTTP
+// requests have no body, query parameters or headers. pattern is the path of
+// the method HTTP route.
+func EncodeParams(build RequestBuilder, enc RequestEncoder, pattern string) jsonrpc.EncodeRequestFunc {
+	return func(ctx context.Context, v interface{}) (json.RawMessage, error) {
+		r, err := build(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		if enc != nil {
+			if err := enc(r, v); err != nil {
+				return nil, err
+			}
+		}
+		var body []byte
+		if r.Body != nil {
+			defer r.Body.Close()
+			if body, err = ioutil.ReadAll(r.Body); err != nil {
+				return nil, err
+			}
+			body = bytes.TrimSpace(body)
+		}
+		vars := matchPath(pattern, r.URL.Path)
+		query := r.URL.Query()
+		if len(vars) == 0 && len(query) == 0 {
+			if len(body) == 0 {
+				return nil, nil
+			}
+			return json.RawMessage(body), nil
+		}
+		fields := make(map[string]json.RawMessage)
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &fields); err != nil {
+				return nil, fmt.Errorf("request body must be a JSON object to be merged with the parameters: %s", err)
+			}
+		}
+		for n, v := range vars {
+			fields[n] = paramJSON(v)
+		}
+		for n, vs := range query {
+			if len(vs) == 1 {
+				fields[n] = paramJSON(vs[0])
+				continue
+			}
+			raws := make([]json.RawMessage, len(vs))
+			for i, v := range vs {
+				raws[i] = paramJSON(v)
+			}
+			b, err := json.Marshal(raws)
+			if err != nil {
+				return nil, err
+			}
+			fields[n] = b
+		}
+		return json.Marshal(fields)
+	}
+}
+
+// EncodeNoParams is the go-kit JSON-RPC encoder of the methods that have no
+// payload.
+func EncodeNoParams(context.Context, interface{}) (json.RawMessage, error) {
+	return nil, nil
+}
+
+// DecodeResult returns a go-kit JSON-RPC decoder that decodes the results with
+// the given goa response decoder. status is the HTTP status code of the
+// method success response. JSON-RPC errors are returned as is.
+func DecodeResult(dec ResponseDecoder, status int) jsonrpc.DecodeResponseFunc {
+	return func(ctx context.Context, res jsonrpc.Response) (interface{}, error) {
+		if res.Error != nil {
+			return nil, *res.Error
+		}
+		resp := &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewReader(res.Result)),
+		}
+		return dec(goahttp.ResponseDecoder, false)(resp)
+	}
+}
+
+// DecodeNoResult is the go-kit JSON-RPC decoder of the methods that have no
+// result.
+func DecodeNoResult(_ context.Context, res jsonrpc.Response) (interface{}, error) {
+	if res.Error != nil {
+		return nil, *res.Error
+	}
+	return nil, nil
+}
+
+// Vars returns the path parameters decoded from the JSON-RPC params.
+func (m *varsMuxer) Vars(*http.Request) map[string]string {
+	return m.vars
+}
+
+// Handle does nothing, the muxer is only used to provide the path parameters
+// to the goa request decoders.
+func (m *varsMuxer) Handle(method, pattern string, handler http.HandlerFunc) {}
+
+// pathParams returns the names of the path parameters defined by the given
+// route pattern, e.g. "id" for "/items/{id}" and "path" for "/files/{*path}".
+func pathParams(pattern string) []string {
+	var names []string
+	for _, s := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			names = append(names, strings.TrimPrefix(s[1:len(s)-1], "*"))
+		}
+	}
+	return names
+}
+
+// matchPath returns the values of the path parameters defined by pattern in
+// the given path.
+func matchPath(pattern, path string) map[string]string {
+	vars := make(map[string]string)
+	ps := strings.Split(pattern, "/")
+	vs := strings.Split(path, "/")
+	for i, s := range ps {
+		if i >= len(vs) {
+			break
+		}
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		name := s[1 : len(s)-1]
+		if strings.HasPrefix(name, "*") {
+			vars[name[1:]] = strings.Join(vs[i:], "/")
+			break
+		}
+		vars[name] = vs[i]
+	}
+	return vars
+}
+
+// paramValues returns the string representations of the given JSON parameter
+// value. Arrays produce one value per element.
+func paramValues(raw json.RawMessage) ([]string, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		elems = []json.RawMessage{raw}
+	}
+	vs := make([]string, len(elems))
+	for i, e := range elems {
+		var s string
+		if err := json.Unmarshal(e, &s); err == nil {
+			vs[i] = s
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(e, &v); err != nil {
+			return nil, err
+		}
+		switch v.(type) {
+		case map[string]interface{}, []interface{}, nil:
+			return nil, fmt.Errorf("invalid parameter value %s", e)
+		}
+		vs[i] = string(bytes.TrimSpace(e))
+	}
+	return vs, nil
+}
+
+// paramJSON returns the JSON representation of the given parameter value:
+// numbers and booleans are kept as is, other values are encoded as strings.
+func paramJSON(v string) json.RawMessage {
+	var n interface{}
+	if err := json.Unmarshal([]byte(v), &n); err == nil {
+		switch n.(type) {
+		case float64, bool:
+			return json.RawMessage(v)
+		}
+	}
+	b, _ := json.Marshal(v)
+	return b
+}
+
+// invalidParams returns a JSON-RPC invalid params error wrapping err.
+func invalidParams(err error) error {
+	return jsonrpc.Error{Code: jsonrpc.InvalidParamsError, Message: err.Error()}
+}
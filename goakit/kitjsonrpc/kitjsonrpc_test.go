@@ -0,0 +1,189 @@
+package kitjsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	goahttp "goa.design/goa/http"
+)
+
+type itemPayload struct {
+	ID   string
+	Tags []string
+	Name string
+}
+
+// decodeItemRequest mimics a goa generated request decoder for a method
+// whose "id" attribute is a path parameter, "tags" a query parameter and
+// "name" a body field.
+func decodeItemRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var body struct {
+			Name *string `json:"name"`
+		}
+		if err := decoder(r).Decode(&body); err != nil {
+			return nil, err
+		}
+		if body.Name == nil {
+			return nil, errors.New(`"name" is missing from body`)
+		}
+		return &itemPayload{
+			ID:   mux.Vars(r)["id"],
+			Tags: r.URL.Query()["tags"],
+			Name: *body.Name,
+		}, nil
+	}
+}
+
+func TestDecodeParams(t *testing.T) {
+	cases := map[string]struct {
+		Params   string
+		Expected *itemPayload
+		Err      bool
+	}{
+		"all":          {`{"id":1,"tags":["a","b"],"name":"item"}`, &itemPayload{ID: "1", Tags: []string{"a", "b"}, Name: "item"}, false},
+		"string-id":    {`{"id":"1","name":"item"}`, &itemPayload{ID: "1", Name: "item"}, false},
+		"missing-name": {`{"id":1}`, nil, true},
+		"invalid-id":   {`{"id":{},"name":"item"}`, nil, true},
+		"not-object":   {`[1]`, nil, true},
+	}
+	dec := DecodeParams(decodeItemRequest, "/items/{id}", "tags")
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := dec(context.Background(), json.RawMessage(c.Params))
+			if c.Err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if e, ok := err.(jsonrpc.Error); !ok || e.Code != jsonrpc.InvalidParamsError {
+					t.Errorf("got error %#v, expected an invalid params error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(v, c.Expected) {
+				t.Errorf("got %+v, expected %+v", v, c.Expected)
+			}
+		})
+	}
+}
+
+func TestEncodeResult(t *testing.T) {
+	enc := func(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+		return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+			w.WriteHeader(http.StatusOK)
+			if v == nil {
+				return nil
+			}
+			return encoder(ctx, w).Encode(v)
+		}
+	}
+	res, err := EncodeResult(enc)(context.Background(), map[string]string{"name": "item"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(res) != `{"name":"item"}` {
+		t.Errorf("got result %s, expected %s", res, `{"name":"item"}`)
+	}
+	res, err = EncodeResult(enc)(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res != nil {
+		t.Errorf("got result %s, expected nil", res)
+	}
+}
+
+func TestEncodeParams(t *testing.T) {
+	build := func(ctx context.Context, v interface{}) (*http.Request, error) {
+		p := v.(*itemPayload)
+		return http.NewRequest("POST", "http://localhost/items/"+p.ID, nil)
+	}
+	enc := func(r *http.Request, v interface{}) error {
+		p := v.(*itemPayload)
+		q := r.URL.Query()
+		for _, t := range p.Tags {
+			q.Add("tags", t)
+		}
+		r.URL.RawQuery = q.Encode()
+		r.Body = ioutil.NopCloser(strings.NewReader(`{"name":"` + p.Name + `"}`))
+		return nil
+	}
+	msg, err := EncodeParams(build, enc, "/items/{id}")(context.Background(), &itemPayload{ID: "1", Tags: []string{"a", "b"}, Name: "item"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `{"id":1,"name":"item","tags":["a","b"]}`
+	if string(msg) != expected {
+		t.Errorf("got params %s, expected %s", msg, expected)
+	}
+
+	// The params decoded by the server match the encoded payload.
+	v, err := DecodeParams(decodeItemRequest, "/items/{id}", "tags")(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := v.(*itemPayload); p.ID != "1" || p.Name != "item" || !reflect.DeepEqual(p.Tags, []string{"a", "b"}) {
+		t.Errorf("got payload %+v", p)
+	}
+}
+
+func TestDecodeResult(t *testing.T) {
+	var status int
+	dec := func(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+		return func(resp *http.Response) (interface{}, error) {
+			status = resp.StatusCode
+			var body map[string]string
+			err := decoder(resp).Decode(&body)
+			return body, err
+		}
+	}
+	res, err := DecodeResult(dec, http.StatusCreated)(context.Background(), jsonrpc.Response{Result: json.RawMessage(`{"name":"item"}`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusCreated {
+		t.Errorf("got status %d, expected %d", status, http.StatusCreated)
+	}
+	if !reflect.DeepEqual(res, map[string]string{"name": "item"}) {
+		t.Errorf("got result %v", res)
+	}
+	rpcErr := &jsonrpc.Error{Code: jsonrpc.InternalError, Message: "boom"}
+	if _, err := DecodeResult(dec, http.StatusOK)(context.Background(), jsonrpc.Response{Error: rpcErr}); err != *rpcErr {
+		t.Errorf("got error %v, expected %v", err, *rpcErr)
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	cases := []struct {
+		Pattern, Path string
+		Expected      map[string]string
+	}{
+		{"/", "/", map[string]string{}},
+		{"/items/{id}", "/items/1", map[string]string{"id": "1"}},
+		{"/items/{id}/tags/{tag}", "/items/1/tags/a", map[string]string{"id": "1", "tag": "a"}},
+		{"/files/{*path}", "/files/a/b.json", map[string]string{"path": "a/b.json"}},
+	}
+	for _, c := range cases {
+		vars := matchPath(c.Pattern, c.Path)
+		if !reflect.DeepEqual(vars, c.Expected) {
+			t.Errorf("matchPath(%q, %q): got %v, expected %v", c.Pattern, c.Path, vars, c.Expected)
+		}
+		var names []string
+		for n := range c.Expected {
+			names = append(names, n)
+		}
+		if got := pathParams(c.Pattern); len(got) != len(names) {
+			t.Errorf("pathParams(%q): got %v, expected %d names", c.Pattern, got, len(names))
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Package kitmock provides the functions used by the mock service
+// implementations that goakit generates with the example command. The mock
+// methods return the examples defined in the design unless the request
+// selects one of the method errors by name using the X-Mock-Error header.
+package kitmock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ErrorHeader is the name of the HTTP request header that holds the name of
+// the error the mock methods return.
+const ErrorHeader = "X-Mock-Error"
+
+// ctxKey is the type of the context key used to store the error name.
+type ctxKey int
+
+// errorKey is the context key used to store the error name.
+const errorKey ctxKey = iota + 1
+
+// Handler returns a HTTP handler that stores the value of the ErrorHeader
+// header of the requests in their context before calling h.
+func Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name := r.Header.Get(ErrorHeader); name != "" {
+			r = r.WithContext(WithError(r.Context(), name))
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// WithError returns a copy of ctx that holds the name of the error the mock
+// methods return.
+func WithError(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, errorKey, name)
+}
+
+// Error returns the name of the error held by ctx, the empty string if the
+// mock methods must not return an error.
+func Error(ctx context.Context) string {
+	name, _ := ctx.Value(errorKey).(string)
+	return name
+}
+
+// NewError returns the error wrapped by the mock methods to build the design
+// errors with the given name.
+func NewError(name string) error {
+	return fmt.Errorf("mock %s error", name)
+}
+
+// UnknownError returns the error returned by the mock methods when the error
+// name does not match any of the method errors.
+func UnknownError(name string) error {
+	return fmt.Errorf("unknown mock error %q", name)
+}
+
+// Bool returns a pointer to v.
+func Bool(v bool) *bool { return &v }
+
+// Int returns a pointer to v.
+func Int(v int) *int { return &v }
+
+// Int32 returns a pointer to v.
+func Int32(v int32) *int32 { return &v }
+
+// Int64 returns a pointer to v.
+func Int64(v int64) *int64 { return &v }
+
+// UInt returns a pointer to v.
+func UInt(v uint) *uint { return &v }
+
+// UInt32 returns a pointer to v.
+func UInt32(v uint32) *uint32 { return &v }
+
+// UInt64 returns a pointer to v.
+func UInt64(v uint64) *uint64 { return &v }
+
+// Float32 returns a pointer to v.
+func Float32(v float32) *float32 { return &v }
+
+// Float64 returns a pointer to v.
+func Float64(v float64) *float64 { return &v }
+
+// String returns a pointer to v.
+func String(v string) *string { return &v }
@@ -0,0 +1,40 @@
+package kitmock
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	cases := map[string]string{
+		"no-header": "",
+		"header":    "not_found",
+	}
+	for name, header := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = Error(r.Context())
+			}))
+			r := httptest.NewRequest("GET", "/", nil)
+			if header != "" {
+				r.Header.Set(ErrorHeader, header)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != header {
+				t.Errorf("got error name %q, expected %q", got, header)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	if name := Error(context.Background()); name != "" {
+		t.Errorf("got error name %q, expected none", name)
+	}
+	if name := Error(WithError(context.Background(), "bad_request")); name != "bad_request" {
+		t.Errorf("got error name %q, expected %q", name, "bad_request")
+	}
+}
@@ -0,0 +1,144 @@
+// Package kitnats makes it possible to serve and call goa methods over the
+// go-kit NATS transport using the goa generated HTTP request decoders,
+// response encoders, request builders and response decoders. The NATS
+// requests hold the same JSON params as the JSON-RPC requests (see package
+// kitjsonrpc): a JSON object with the fields of the HTTP request body together
+// with the path and query parameters of the method HTTP route. The replies are
+// JSON objects holding either the result, that is the HTTP response body, or
+// the error.
+//
+// The functions are used by the code that goakit generates in the kitnats
+// package of the services that enable the NATS transport in the design.
+package kitnats
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-kit/kit/transport/http/jsonrpc"
+	natstransport "github.com/go-kit/kit/transport/nats"
+	"github.com/nats-io/nats.go"
+	"goa.design/plugins/goakit/kitjsonrpc"
+)
+
+type (
+	// Reply is the JSON representation of the replies to the NATS
+	// requests.
+	Reply struct {
+		// Result is the method result, absent if the method has no
+		// result or if the request failed.
+		Result json.RawMessage `json:"result,omitempty"`
+		// Error describes the failure, nil if the request succeeded.
+		Error *Error `json:"error,omitempty"`
+	}
+
+	// Error is the error returned by the clients when the request fails.
+	Error struct {
+		// Message is the error message.
+		Message string `json:"message"`
+	}
+)
+
+// DecodeRequest returns a go-kit NATS decoder that decodes the request data
+// with the given goa request decoder. pattern is the path of the method HTTP
+// route and query lists the names of its query parameters, see
+// kitjsonrpc.DecodeParams.
+func DecodeRequest(dec kitjsonrpc.RequestDecoder, pattern string, query ...string) natstransport.DecodeRequestFunc {
+	decode := kitjsonrpc.DecodeParams(dec, pattern, query...)
+	return func(ctx context.Context, msg *nats.Msg) (interface{}, error) {
+		v, err := decode(ctx, json.RawMessage(msg.Data))
+		if e, ok := err.(jsonrpc.Error); ok {
+			return nil, &Error{Message: e.Message}
+		}
+		return v, err
+	}
+}
+
+// DecodeNoRequest is the go-kit NATS decoder of the methods that have no
+// payload.
+func DecodeNoRequest(context.Context, *nats.Msg) (interface{}, error) {
+	return nil, nil
+}
+
+// EncodeResponse returns a go-kit NATS encoder that encodes the results with
+// the given goa response encoder and publishes them to the reply subject.
+func EncodeResponse(enc kitjsonrpc.ResponseEncoder) natstransport.EncodeResponseFunc {
+	encode := kitjsonrpc.EncodeResult(enc)
+	return func(ctx context.Context, reply string, nc *nats.Conn, v interface{}) error {
+		res, err := encode(ctx, v)
+		if err != nil {
+			return err
+		}
+		return publish(nc, reply, &Reply{Result: res})
+	}
+}
+
+// EncodeError is the go-kit NATS error encoder that publishes the errors to
+// the reply subject.
+func EncodeError(_ context.Context, err error, reply string, nc *nats.Conn) {
+	publish(nc, reply, &Reply{Error: &Error{Message: err.Error()}})
+}
+
+// EncodeRequest returns a go-kit NATS encoder that encodes the payloads with
+// the given goa request builder and encoder, see kitjsonrpc.EncodeParams.
+func EncodeRequest(build kitjsonrpc.RequestBuilder, enc kitjsonrpc.RequestEncoder, pattern string) natstransport.EncodeRequestFunc {
+	encode := kitjsonrpc.EncodeParams(build, enc, pattern)
+	return func(ctx context.Context, msg *nats.Msg, v interface{}) error {
+		data, err := encode(ctx, v)
+		if err != nil {
+			return err
+		}
+		msg.Data = data
+		return nil
+	}
+}
+
+// EncodeNoRequest is the go-kit NATS encoder of the methods that have no
+// payload.
+func EncodeNoRequest(context.Context, *nats.Msg, interface{}) error {
+	return nil
+}
+
+// DecodeResponse returns a go-kit NATS decoder that decodes the results with
+// the given goa response decoder. status is the HTTP status code of the method
+// success response. The errors are returned as *Error values.
+func DecodeResponse(dec kitjsonrpc.ResponseDecoder, status int) natstransport.DecodeResponseFunc {
+	decode := kitjsonrpc.DecodeResult(dec, status)
+	return func(ctx context.Context, msg *nats.Msg) (interface{}, error) {
+		var r Reply
+		if err := json.Unmarshal(msg.Data, &r); err != nil {
+			return nil, err
+		}
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		return decode(ctx, jsonrpc.Response{Result: r.Result})
+	}
+}
+
+// DecodeNoResponse is the go-kit NATS decoder of the methods that have no
+// result. The errors are returned as *Error values.
+func DecodeNoResponse(_ context.Context, msg *nats.Msg) (interface{}, error) {
+	var r Reply
+	if err := json.Unmarshal(msg.Data, &r); err != nil {
+		return nil, err
+	}
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	return nil, nil
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// publish publishes the JSON representation of r to the given subject.
+func publish(nc *nats.Conn, subject string, r *Reply) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return nc.Publish(subject, b)
+}
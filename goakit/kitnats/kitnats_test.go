@@ -0,0 +1,130 @@
+package kitnats
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	natstransport "github.com/go-kit/kit/transport/nats"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	goahttp "goa.design/goa/http"
+)
+
+type itemPayload struct {
+	ID   string
+	Name string
+}
+
+// decodeItemRequest mimics a goa generated request decoder for a method
+// whose "id" attribute is a path parameter and "name" a body field.
+func decodeItemRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var body struct {
+			Name *string `json:"name"`
+		}
+		if err := decoder(r).Decode(&body); err != nil {
+			return nil, err
+		}
+		if body.Name == nil {
+			return nil, errors.New(`"name" is missing from body`)
+		}
+		return &itemPayload{ID: mux.Vars(r)["id"], Name: *body.Name}, nil
+	}
+}
+
+// encodeItemResponse mimics a goa generated response encoder.
+func encodeItemResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		w.WriteHeader(http.StatusOK)
+		return encoder(ctx, w).Encode(v)
+	}
+}
+
+// buildItemRequest mimics a goa generated request builder.
+func buildItemRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	return http.NewRequest("PUT", "http://localhost/items/"+v.(*itemPayload).ID, nil)
+}
+
+// encodeItemRequest mimics a goa generated request encoder.
+func encodeItemRequest(r *http.Request, v interface{}) error {
+	r.Body = ioutil.NopCloser(strings.NewReader(`{"name":"` + v.(*itemPayload).Name + `"}`))
+	return nil
+}
+
+// decodeItemResponse mimics a goa generated response decoder.
+func decodeItemResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		var body itemPayload
+		err := decoder(resp).Decode(&body)
+		return &body, err
+	}
+}
+
+func TestRequestReply(t *testing.T) {
+	s := natsserver.RunRandClientPortServer()
+	defer s.Shutdown()
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("failed to connect to NATS server: %v", err)
+	}
+	defer nc.Close()
+
+	echo := func(_ context.Context, v interface{}) (interface{}, error) {
+		if p := v.(*itemPayload); p.Name == "fail" {
+			return nil, errors.New("boom")
+		}
+		return v, nil
+	}
+	sub := natstransport.NewSubscriber(
+		echo,
+		DecodeRequest(decodeItemRequest, "/items/{id}"),
+		EncodeResponse(encodeItemResponse),
+		natstransport.SubscriberErrorEncoder(EncodeError),
+	)
+	if _, err := nc.QueueSubscribe("items.update", "items", sub.ServeMsg(nc)); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	e := natstransport.NewPublisher(
+		nc,
+		"items.update",
+		EncodeRequest(buildItemRequest, encodeItemRequest, "/items/{id}"),
+		DecodeResponse(decodeItemResponse, http.StatusOK),
+	).Endpoint()
+
+	p := &itemPayload{ID: "1", Name: "item"}
+	res, err := e(context.Background(), p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(res, p) {
+		t.Errorf("got result %+v, expected %+v", res, p)
+	}
+
+	_, err = e(context.Background(), &itemPayload{ID: "1", Name: "fail"})
+	if e, ok := err.(*Error); !ok || e.Message != "boom" {
+		t.Errorf("got error %#v, expected the endpoint error", err)
+	}
+}
+
+func TestDecodeRequestInvalid(t *testing.T) {
+	dec := DecodeRequest(decodeItemRequest, "/items/{id}")
+	_, err := dec(context.Background(), &nats.Msg{Data: []byte(`{"id":1}`)})
+	if _, ok := err.(*Error); !ok {
+		t.Errorf("got error %#v, expected a *Error", err)
+	}
+}
+
+func TestDecodeNoResponse(t *testing.T) {
+	if _, err := DecodeNoResponse(context.Background(), &nats.Msg{Data: []byte(`{}`)}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err := DecodeNoResponse(context.Background(), &nats.Msg{Data: []byte(`{"error":{"message":"boom"}}`)})
+	if e, ok := err.(*Error); !ok || e.Message != "boom" {
+		t.Errorf("got error %#v, expected the reply error", err)
+	}
+}
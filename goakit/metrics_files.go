@@ -0,0 +1,92 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+// MetricsFiles produces the file defining the kitmetrics package which creates
+// the go-kit Prometheus metrics that instrument the API endpoints. The metric
+// names are prefixed with the API name so that they are consistent across the
+// API services.
+func MetricsFiles() []*codegen.File {
+	path := filepath.Join(codegen.Gendir, "kitmetrics", "metrics.go")
+	title := fmt.Sprintf("%s go-kit Prometheus metrics", design.Root.API.Name)
+	data := map[string]interface{}{
+		"APIName":   design.Root.API.Name,
+		"Namespace": metricsNamespace(design.Root.API.Name),
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "kitmetrics", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/metrics"},
+			{Path: "github.com/go-kit/kit/metrics/prometheus", Name: "kitprometheus"},
+			{Path: "github.com/prometheus/client_golang/prometheus"},
+			{Path: "goa.design/plugins/goakit/kitendpoint"},
+		}),
+		{
+			Name:   "goakit-metrics",
+			Source: metricsT,
+			Data:   data,
+		},
+	}
+
+	return []*codegen.File{{Path: path, SectionTemplates: sections}}
+}
+
+// metricsNamespace returns the Prometheus namespace derived from the given API
+// name. Prometheus metric names may only contain letters, digits and
+// underscores.
+func metricsNamespace(api string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(codegen.SnakeCase(api)))
+}
+
+// input: map[string]interface{}{"APIName": string, "Namespace": string}
+const metricsT = `{{ printf "Namespace is the Prometheus namespace of the %s API metrics." .APIName | comment }}
+const Namespace = {{ printf "%q" .Namespace }}
+
+{{ printf "Metrics holds the go-kit Prometheus metrics that instrument the %s API endpoints. The metrics are labeled with \"service\", \"method\" and \"error\", the name of the error returned by the endpoint as defined in the design, \"unknown\" if the error is not a designed error or the empty string if the request succeeded." .APIName | comment }}
+type Metrics struct {
+	// Requests counts the requests.
+	Requests metrics.Counter
+	// Duration records the duration of the requests in seconds.
+	Duration metrics.Histogram
+}
+
+// New creates the metrics and registers them with the default Prometheus
+// registry. New panics if called more than once as the metrics are already
+// registered.
+func New() *Metrics {
+	labels := []string{"service", "method", "error"}
+	return &Metrics{
+		Requests: kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests received.",
+		}, labels),
+		Duration: kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests in seconds.",
+		}, labels),
+	}
+}
+
+// Middleware returns the kitendpoint.Instrumenting middlewares that record the
+// metrics of the given service methods. The function is suitable for the
+// Apply method of the service Endpoints struct:
+//
+//	endpoints.Apply(m.Middleware(calcsvc.ServiceName))
+func (m *Metrics) Middleware(service string) func(method string) endpoint.Middleware {
+	return kitendpoint.Instrumenting(m.Duration, m.Requests, service)
+}
+`
@@ -0,0 +1,46 @@
+package goakit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestMetricsFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code string
+	}{
+		"api-name": {testdata.MetricsDSL, testdata.MetricsCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := MetricsFiles()
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			path := filepath.Join(codegen.Gendir, "kitmetrics", "metrics.go")
+			if fs[0].Path != path {
+				t.Errorf("got path %q, expected %q", fs[0].Path, path)
+			}
+			testCode(t, fs[0], "goakit-metrics", []string{c.Code})
+		})
+	}
+}
+
+func TestMetricsNamespace(t *testing.T) {
+	cases := map[string]string{
+		"calc":     "calc",
+		"calc-api": "calc_api",
+		"calc api": "calc_api",
+	}
+	for api, expected := range cases {
+		if ns := metricsNamespace(api); ns != expected {
+			t.Errorf("%q: got namespace %q, expected %q", api, ns, expected)
+		}
+	}
+}
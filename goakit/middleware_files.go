@@ -0,0 +1,131 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+)
+
+// MiddlewareFiles produces the files defining the go-kit style service
+// middlewares. Services with streaming methods are skipped as the streams
+// cannot be decorated generically.
+func MiddlewareFiles(genpkg string) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range design.Root.Services {
+		data := service.Services.Get(svc.Name)
+		if !hasMiddleware(data) {
+			continue
+		}
+		fw = append(fw, middlewareFile(data))
+	}
+	return fw
+}
+
+// middlewareFile returns the file defining the Middleware type and the logging
+// and instrumenting middlewares of the given service.
+func middlewareFile(data *service.Data) *codegen.File {
+	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(data.Name), "middleware.go")
+	title := fmt.Sprintf("%s service middlewares", data.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, data.PkgName, []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "strconv"},
+			{Path: "time"},
+			{Path: "github.com/go-kit/kit/log"},
+			{Path: "github.com/go-kit/kit/metrics"},
+		}),
+		{
+			Name:   "goakit-middleware",
+			Source: middlewareT,
+			Data:   data,
+		},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-logging-middleware-method",
+			Source: loggingMiddlewareMethodT,
+			Data:   m,
+		})
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-instrumenting-middleware-method",
+			Source: instrumentingMiddlewareMethodT,
+			Data:   m,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// hasMiddleware returns true if the service middlewares are generated for the
+// service described by the given data.
+func hasMiddleware(data *service.Data) bool {
+	if len(data.Methods) == 0 {
+		return false
+	}
+	for _, m := range data.Methods {
+		if m.ServerStream != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// input: service.Data
+const middlewareT = `{{ printf "Middleware is a %s service middleware." .Name | comment }}
+type Middleware func(Service) Service
+
+{{ printf "LoggingMiddleware returns a %s service middleware that logs the method names, the duration and the error (if any) of the method calls." .Name | comment }}
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{logger: logger, next: next}
+	}
+}
+
+{{ printf "InstrumentingMiddleware returns a %s service middleware that records the duration of the method calls in seconds with the given histogram and counts them with the given counter. Both metrics are labeled with \"service\", \"method\" and \"success\" (\"true\" if the method returned no error, \"false\" otherwise)." .Name | comment }}
+func InstrumentingMiddleware(duration metrics.Histogram, requests metrics.Counter) Middleware {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{duration: duration, requests: requests, next: next}
+	}
+}
+
+// loggingMiddleware is the service middleware built by LoggingMiddleware.
+type loggingMiddleware struct {
+	logger log.Logger
+	next   Service
+}
+
+// instrumentingMiddleware is the service middleware built by
+// InstrumentingMiddleware.
+type instrumentingMiddleware struct {
+	duration metrics.Histogram
+	requests metrics.Counter
+	next     Service
+}
+`
+
+// input: service.MethodData
+const loggingMiddlewareMethodT = `{{ printf "%s logs the %s method calls." .VarName .Name | comment }}
+func (mw *loggingMiddleware) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) ({{ if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("service", ServiceName, "method", {{ printf "%q" .Name }}, "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.{{ .VarName }}(ctx{{ if .PayloadRef }}, p{{ end }})
+}
+`
+
+// input: service.MethodData
+const instrumentingMiddlewareMethodT = `{{ printf "%s records the duration and count of the %s method calls." .VarName .Name | comment }}
+func (mw *instrumentingMiddleware) {{ .VarName }}(ctx context.Context{{ if .PayloadRef }}, p {{ .PayloadRef }}{{ end }}) ({{ if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"service", ServiceName, "method", {{ printf "%q" .Name }}, "success", strconv.FormatBool(err == nil)}
+		mw.duration.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.requests.With(lvs...).Add(1)
+	}(time.Now())
+	return mw.next.{{ .VarName }}(ctx{{ if .PayloadRef }}, p{{ end }})
+}
+`
@@ -0,0 +1,62 @@
+package goakit
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestMiddlewareFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL           func()
+		Logging       string
+		Instrumenting string
+	}{
+		"simple-service": {testdata.SimpleServiceDSL, testdata.SimpleServiceLoggingMiddlewareCode, testdata.SimpleServiceInstrumentingMiddlewareCode},
+		"with-payload":   {testdata.WithPayloadDSL, testdata.WithPayloadLoggingMiddlewareCode, testdata.WithPayloadInstrumentingMiddlewareCode},
+		"with-result":    {testdata.WithResultDSL, testdata.WithResultLoggingMiddlewareCode, testdata.WithResultInstrumentingMiddlewareCode},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := MiddlewareFiles("")
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			sections := fs[0].Section("goakit-logging-middleware-method")
+			if len(sections) != 1 {
+				t.Fatalf("got %d logging sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Logging {
+				t.Errorf("invalid logging code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Logging))
+			}
+			sections = fs[0].Section("goakit-instrumenting-middleware-method")
+			if len(sections) != 1 {
+				t.Fatalf("got %d instrumenting sections, expected 1", len(sections))
+			}
+			code = codegen.SectionCode(t, sections[0])
+			if code != c.Instrumenting {
+				t.Errorf("invalid instrumenting code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Instrumenting))
+			}
+		})
+	}
+}
+
+func TestMiddlewareType(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SimpleServiceDSL)
+	fs := MiddlewareFiles("")
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	sections := fs[0].Section("goakit-middleware")
+	if len(sections) != 1 {
+		t.Fatalf("got %d sections, expected 1", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.SimpleServiceMiddlewareCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.SimpleServiceMiddlewareCode))
+	}
+}
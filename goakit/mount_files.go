@@ -54,7 +54,11 @@ func mountFile(svc *httpdesign.ServiceExpr) *codegen.File {
 }
 
 // input: EndpointData
-const mountHandlerT = `{{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
+const mountHandlerT = `{{ if .ServerStream -}}
+{{ printf "%s configures the mux to serve the %q service %q endpoint. goakit generates no go-kit transport for the streaming endpoint, h must be the WebSocket handler built by the goa generated server." .MountHandler .ServiceName .Method.Name | comment }}
+{{- else -}}
+{{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
+{{- end }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
@@ -34,6 +34,13 @@ func TestMountFiles(t *testing.T) {
 				"goakit-mount-file-server": []string{testdata.MixedFileGoakitMountCode},
 			},
 		},
+		"streaming": {
+			DSL: testdata.StreamingDSL,
+			Code: map[string][]string{
+				"goakit-mount-handler":     []string{testdata.StreamingMethodGoakitMountCode, testdata.UnaryMethodGoakitMountCode},
+				"goakit-mount-file-server": []string{},
+			},
+		},
 	}
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
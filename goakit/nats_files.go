@@ -0,0 +1,194 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/design"
+)
+
+// NATSFiles produces the files containing the go-kit NATS subscribers and
+// publishers of the services that enable the NATS transport in the design.
+// The NATS codecs reuse the goa generated HTTP encoders and decoders so that
+// the payloads are validated the same way as the HTTP requests.
+func NATSFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		n := design.Root.NATS(svc.ServiceExpr)
+		if n == nil {
+			continue
+		}
+		fw = append(fw, natsServerFile(genpkg, svc, n), natsClientFile(genpkg, svc, n))
+	}
+	return fw
+}
+
+// natsServerFile returns the file defining the go-kit NATS subscribers of the
+// given service.
+func natsServerFile(genpkg string, svc *httpdesign.ServiceExpr, n *design.NATSExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "nats", codegen.SnakeCase(svc.Name()), "kitnats", "server.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit NATS server", svc.Name())
+	fm := codegen.TemplateFuncs()
+	fm["subject"] = n.Subject
+	fm["queryParams"] = queryParams
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "nats", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/transport/nats", Name: "natstransport"},
+			{Path: "github.com/nats-io/nats.go"},
+			{Path: "goa.design/plugins/goakit/kitnats"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/server"},
+		}),
+		{
+			Name:    "goakit-nats-subjects",
+			Source:  natsSubjectsT,
+			Data:    data,
+			FuncMap: fm,
+		},
+		{
+			Name:   "goakit-nats-subscribe",
+			Source: natsSubscribeT,
+			Data:   data,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "goakit-nats-server",
+			Source:  natsServerT,
+			Data:    e,
+			FuncMap: fm,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// natsClientFile returns the file defining the go-kit NATS publishers of the
+// given service.
+func natsClientFile(genpkg string, svc *httpdesign.ServiceExpr, n *design.NATSExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "nats", codegen.SnakeCase(svc.Name()), "kitnats", "client.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit NATS client", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "nats", []*codegen.ImportSpec{
+			{Path: "net/http"},
+			{Path: "github.com/go-kit/kit/transport/nats", Name: "natstransport"},
+			{Path: "github.com/nats-io/nats.go"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: "goa.design/plugins/goakit/kitnats"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+			{Path: genpkg + "/http/" + data.Service.Name + "/client"},
+		}),
+		{
+			Name:   "goakit-nats-client-new",
+			Source: natsClientNewT,
+			Data:   data,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-nats-client",
+			Source: natsClientT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const natsSubjectsT = `{{ printf "Queue is the name of the queue group joined by the %s service subscribers so that each request is served once." .Service.Name | comment }}
+const Queue = {{ printf "%q" .Service.Name }}
+
+const (
+{{- range .Endpoints }}
+	{{ printf "%sSubject is the subject of the NATS requests made to the %s service %s method." .Method.VarName .ServiceName .Method.Name | comment }}
+	{{ .Method.VarName }}Subject = {{ printf "%q" (subject .Method.Name) }}
+{{- end }}
+)
+`
+
+// input: ServiceData
+const natsSubscribeT = `{{ printf "Subscribe subscribes the %s service NATS servers to the method subjects using the Queue queue group. The servers publish the replies using the given connection." .Service.Name | comment }}
+func Subscribe(nc *nats.Conn, e *{{ .Service.PkgName }}.Endpoints, opts ...natstransport.SubscriberOption) ([]*nats.Subscription, error) {
+	servers := map[string]*natstransport.Subscriber{
+	{{- range .Endpoints }}
+		{{ .Method.VarName }}Subject: New{{ .Method.VarName }}Server(e.{{ .Method.VarName }}, opts...),
+	{{- end }}
+	}
+	subs := make([]*nats.Subscription, 0, len(servers))
+	for subject, s := range servers {
+		sub, err := nc.QueueSubscribe(subject, Queue, s.ServeMsg(nc))
+		if err != nil {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+`
+
+// input: EndpointData
+const natsServerT = `{{ if .Payload.Ref -}}
+{{ printf "New%sServer returns a go-kit NATS subscriber that serves the %s service %s method. The request data is decoded with the goa generated HTTP request decoder so that it is validated like the HTTP requests." .Method.VarName .ServiceName .Method.Name | comment }}
+{{- else -}}
+{{ printf "New%sServer returns a go-kit NATS subscriber that serves the %s service %s method." .Method.VarName .ServiceName .Method.Name | comment }}
+{{- end }}
+func New{{ .Method.VarName }}Server(e endpoint.Endpoint, opts ...natstransport.SubscriberOption) *natstransport.Subscriber {
+	return natstransport.NewSubscriber(
+		e,
+	{{- if .Payload.Ref }}
+		kitnats.DecodeRequest(server.{{ .RequestDecoder }}, {{ printf "%q" (index .Routes 0).Path }}{{ queryParams . }}),
+	{{- else }}
+		kitnats.DecodeNoRequest,
+	{{- end }}
+		kitnats.EncodeResponse(server.{{ .ResponseEncoder }}),
+		append([]natstransport.SubscriberOption{natstransport.SubscriberErrorEncoder(kitnats.EncodeError)}, opts...)...,
+	)
+}
+`
+
+// input: ServiceData
+const natsClientNewT = `{{ printf "New returns the %s service endpoints implemented with go-kit NATS publishers that make requests using the given connection." .Service.Name | comment }}
+func New(nc *nats.Conn, opts ...natstransport.PublisherOption) *{{ .Service.PkgName }}.Endpoints {
+	return &{{ .Service.PkgName }}.Endpoints{
+	{{- range .Endpoints }}
+		{{ .Method.VarName }}: New{{ .Method.VarName }}Client(nc, opts...).Endpoint(),
+	{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const natsClientT = `{{ printf "New%sClient returns a go-kit NATS publisher that makes requests to the %s service %s method." .Method.VarName .ServiceName .Method.Name | comment }}
+func New{{ .Method.VarName }}Client(nc *nats.Conn, opts ...natstransport.PublisherOption) *natstransport.Publisher {
+{{- if .Payload.Ref }}
+	// The HTTP client is only used to build the request params, it makes no
+	// HTTP request.
+	c := client.NewClient("", "", nil, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+{{- end }}
+	return natstransport.NewPublisher(
+		nc,
+		{{ .Method.VarName }}Subject,
+	{{- if .Payload.Ref }}
+		kitnats.EncodeRequest(c.Build{{ .Method.VarName }}Request, {{ if .RequestEncoder }}client.{{ .RequestEncoder }}(goahttp.RequestEncoder){{ else }}nil{{ end }}, {{ printf "%q" (index .Routes 0).Path }}),
+	{{- else }}
+		kitnats.EncodeNoRequest,
+	{{- end }}
+	{{- if .Result }}
+		kitnats.DecodeResponse(client.{{ .ResponseDecoder }}, {{ (index .Result.Responses 0).StatusCode }}),
+	{{- else }}
+		kitnats.DecodeNoResponse,
+	{{- end }}
+		opts...,
+	)
+}
+`
@@ -0,0 +1,36 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestNATSFiles(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.NATSDSL)
+	fs := NATSFiles("", httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	testCode(t, fs[0], "goakit-nats-subjects", []string{testdata.NATSServiceNATSSubjectsCode})
+	testCode(t, fs[0], "goakit-nats-subscribe", []string{testdata.NATSServiceNATSSubscribeCode})
+	testCode(t, fs[0], "goakit-nats-server", []string{
+		testdata.ParamsMethodNATSServerCode,
+		testdata.NoPayloadMethodNATSServerCode,
+	})
+	testCode(t, fs[1], "goakit-nats-client-new", []string{testdata.NATSServiceNATSClientNewCode})
+	testCode(t, fs[1], "goakit-nats-client", []string{
+		testdata.ParamsMethodNATSClientCode,
+		testdata.NoPayloadMethodNATSClientCode,
+	})
+}
+
+func TestNATSFilesDisabled(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.JSONRPCDSL)
+	fs := NATSFiles("", httpdesign.Root)
+	if len(fs) != 0 {
+		t.Errorf("got %d files, expected 0", len(fs))
+	}
+}
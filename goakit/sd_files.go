@@ -0,0 +1,171 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// SDFiles produces the files containing the go-kit service discovery
+// factories and the load balanced endpoints constructors.
+func SDFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		if len(kitServiceData(httpcodegen.HTTPServices.Get(svc.Name())).Endpoints) == 0 {
+			continue
+		}
+		fw = append(fw, sdFile(genpkg, svc))
+	}
+	return fw
+}
+
+// sdFile returns the file defining the go-kit service discovery factories and
+// the load balanced endpoints constructor for the given service.
+func sdFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "sd.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit service discovery", svc.Name())
+	fm := codegen.TemplateFuncs()
+	fm["balanceArgs"] = balanceArgs
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "io"},
+			{Path: "net/http"},
+			{Path: "net/url"},
+			{Path: "strings"},
+			{Path: "time"},
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/log"},
+			{Path: "github.com/go-kit/kit/sd"},
+			{Path: "github.com/go-kit/kit/sd/lb"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: filepath.Join(genpkg, svc.Name()), Name: data.Service.PkgName},
+		}),
+		{
+			Name:    "goakit-load-balanced-endpoints",
+			Source:  loadBalancedEndpointsT,
+			Data:    data,
+			FuncMap: fm,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-sd-factory",
+			Source: sdFactoryT,
+			Data:   e,
+		})
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "goakit-sd-instance",
+		Source: sdInstanceT,
+	})
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const loadBalancedEndpointsT = `// LoadBalancerOptions configures the endpoints returned by
+// NewLoadBalancedEndpoints. The zero value of each field selects the default.
+type LoadBalancerOptions struct {
+	// Scheme is the scheme used to make requests to the instances that do
+	// not specify one, "http" by default.
+	Scheme string
+	// Encoder builds the request body encoders, goahttp.RequestEncoder by
+	// default.
+	Encoder func(*http.Request) goahttp.Encoder
+	// Decoder builds the response body decoders, goahttp.ResponseDecoder by
+	// default.
+	Decoder func(*http.Response) goahttp.Decoder
+	// MaxAttempts is the maximum number of attempts made for each request
+	// to a method marked as idempotent in the design across the instances,
+	// 3 by default. The requests to the methods that define retries in the
+	// design are attempted the number of times defined in the design, the
+	// requests to the other methods are attempted once.
+	MaxAttempts int
+	// Timeout is the maximum duration of each request including all the
+	// attempts, 10 seconds by default. The timeout defined in the design
+	// takes precedence.
+	Timeout time.Duration
+	// ClientOptions are the options used to create the go-kit HTTP clients.
+	ClientOptions []kithttp.ClientOption
+}
+
+{{ printf "NewLoadBalancedEndpoints returns the %s service endpoints implemented with go-kit HTTP clients that make requests to the instances published by the given instancer. The requests are balanced across the instances using a round-robin strategy and the failed requests to the idempotent methods are retried on the next instance. The circuit breakers defined in the design wrap the endpoint of each instance. The instances are either \"host:port\" strings or URLs. opts may be nil." .Service.Name | comment }}
+func NewLoadBalancedEndpoints(instancer sd.Instancer, logger log.Logger, opts *LoadBalancerOptions) *{{ .Service.PkgName }}.Endpoints {
+	o := LoadBalancerOptions{
+		Scheme:      "http",
+		Encoder:     goahttp.RequestEncoder,
+		Decoder:     goahttp.ResponseDecoder,
+		MaxAttempts: 3,
+		Timeout:     10 * time.Second,
+	}
+	if opts != nil {
+		if opts.Scheme != "" {
+			o.Scheme = opts.Scheme
+		}
+		if opts.Encoder != nil {
+			o.Encoder = opts.Encoder
+		}
+		if opts.Decoder != nil {
+			o.Decoder = opts.Decoder
+		}
+		if opts.MaxAttempts > 0 {
+			o.MaxAttempts = opts.MaxAttempts
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		o.ClientOptions = opts.ClientOptions
+	}
+	balance := func(f sd.Factory, attempts int, timeout time.Duration, mw endpoint.Middleware) endpoint.Endpoint {
+		if mw != nil {
+			factory := f
+			f = func(instance string) (endpoint.Endpoint, io.Closer, error) {
+				e, c, err := factory(instance)
+				if err != nil {
+					return nil, nil, err
+				}
+				return mw(e), c, nil
+			}
+		}
+		endpointer := sd.NewEndpointer(instancer, f, logger)
+		return lb.Retry(attempts, timeout, lb.NewRoundRobin(endpointer))
+	}
+	return &{{ .Service.PkgName }}.Endpoints{
+	{{- range .Endpoints }}
+		{{ .Method.VarName }}: balance(New{{ .Method.VarName }}Factory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), {{ balanceArgs .ServiceName .Method.Name .Method.VarName }}),
+	{{- end }}
+	}
+}
+`
+
+// input: EndpointData
+const sdFactoryT = `{{ printf "New%sFactory returns a go-kit sd.Factory that builds the %s service %s endpoint for a given instance. The instance is either a \"host:port\" string in which case the given scheme is used or a URL." .Method.VarName .ServiceName .Method.Name | comment }}
+func New{{ .Method.VarName }}Factory(scheme string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) sd.Factory {
+	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		s, host, err := splitInstance(scheme, instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		return New{{ .Method.VarName }}Client(s, host, enc, dec, opts...).Endpoint(), nil, nil
+	}
+}
+`
+
+const sdInstanceT = `// splitInstance returns the scheme and host of the given service discovery
+// instance. scheme is returned if the instance does not specify one.
+func splitInstance(scheme, instance string) (string, string, error) {
+	if !strings.Contains(instance, "://") {
+		return scheme, instance, nil
+	}
+	u, err := url.Parse(instance)
+	if err != nil {
+		return "", "", err
+	}
+	return u.Scheme, u.Host, nil
+}
+`
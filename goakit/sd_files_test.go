@@ -0,0 +1,49 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestSDFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code map[string][]string
+	}{
+		"simple-service": {
+			DSL: testdata.SimpleServiceDSL,
+			Code: map[string][]string{
+				"goakit-load-balanced-endpoints": []string{testdata.SimpleServiceLoadBalancedEndpointsCode},
+				"goakit-sd-factory":              []string{testdata.SimpleMethodSDFactoryCode},
+			},
+		},
+		"multi-endpoints": {
+			DSL: testdata.MultiEndpointDSL,
+			Code: map[string][]string{
+				"goakit-load-balanced-endpoints": []string{testdata.MultiEndpointServiceLoadBalancedEndpointsCode},
+				"goakit-sd-factory":              []string{testdata.Endpoint1SDFactoryCode, testdata.Endpoint2SDFactoryCode},
+			},
+		},
+		"client-settings": {
+			DSL: testdata.ClientSettingsDSL,
+			Code: map[string][]string{
+				"goakit-load-balanced-endpoints": []string{testdata.ClientServiceLoadBalancedEndpointsCode},
+			},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := SDFiles("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			for sec, secCode := range c.Code {
+				testCode(t, fs[0], sec, secCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	secdesign "goa.design/plugins/security/design"
+)
+
+type (
+	// securityData contains the data necessary to render the go-kit
+	// security code of a service.
+	securityData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// Schemes lists the security schemes used by the service
+		// endpoints in the order they are defined in the design.
+		Schemes []*securitySchemeData
+		// Endpoints lists the secured endpoints.
+		Endpoints []*securityEndpointData
+	}
+
+	// securitySchemeData contains the data necessary to render the go-kit
+	// code of a security scheme.
+	securitySchemeData struct {
+		// Name is the name of the scheme as defined in the design.
+		Name string
+		// VarName is the name of the Authorizers field and the prefix of
+		// the client option function of the scheme.
+		VarName string
+		// Kind is the kind of the scheme, one of "BasicAuth", "APIKey",
+		// "OAuth2" or "JWT".
+		Kind string
+		// AuthorizerType is the type of the Authorizers field of the
+		// scheme.
+		AuthorizerType string
+		// Authorizer is the expression that builds the go-kit middleware
+		// that authorizes the requests secured with the scheme from the
+		// Authorizers field.
+		Authorizer string
+		// ServerBefore is the expression that builds the go-kit HTTP
+		// request function that reads the scheme credentials.
+		ServerBefore string
+		// ClientParams lists the parameters of the client option
+		// function.
+		ClientParams string
+		// ClientBefore is the expression that builds the go-kit HTTP
+		// request function that writes the scheme credentials.
+		ClientBefore string
+	}
+
+	// securityEndpointData contains the data necessary to render the go-kit
+	// security code of an endpoint.
+	securityEndpointData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// MethodName is the name of the method.
+		MethodName string
+		// VarName is the Go name of the method.
+		VarName string
+		// ServerBefore lists the expressions that build the go-kit HTTP
+		// request functions that read the credentials of the endpoint
+		// schemes.
+		ServerBefore []string
+		// Requirements lists the middleware expressions of the schemes
+		// of each security requirement, any one requirement must be
+		// satisfied for the request to be authorized.
+		Requirements [][]string
+	}
+)
+
+// SecurityFiles produces the files that map the security schemes and
+// requirements defined with the security plugin DSL to the go-kit auth
+// middlewares. The server file defines the ServerBefore options that read the
+// credentials and the endpoint middlewares that enforce the requirements, the
+// client file defines the ClientBefore options that write the credentials.
+// No file is produced for the services that have no secured endpoint.
+func SecurityFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		data := buildSecurityData(kitServiceData(httpcodegen.HTTPServices.Get(svc.Name())))
+		if len(data.Endpoints) == 0 {
+			continue
+		}
+		fw = append(fw, securityServerFile(svc, data), securityClientFile(svc, data))
+	}
+	return fw
+}
+
+// securityServerFile returns the file defining the go-kit HTTP server security
+// options and middlewares of the given service.
+func securityServerFile(svc *httpdesign.ServiceExpr, data *securityData) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitserver", "security.go")
+	title := fmt.Sprintf("%s go-kit HTTP server security", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/auth/jwt", Name: "kitjwt"},
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "goa.design/plugins/goakit/kitauth"},
+		}),
+		{
+			Name:   "goakit-security-authorizers",
+			Source: securityAuthorizersT,
+			Data:   data,
+		},
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-security-server",
+			Source: securityServerT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// securityClientFile returns the file defining the go-kit HTTP client security
+// options of the given service.
+func securityClientFile(svc *httpdesign.ServiceExpr, data *securityData) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "security.go")
+	title := fmt.Sprintf("%s go-kit HTTP client security", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/auth/jwt", Name: "kitjwt"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "goa.design/plugins/goakit/kitauth"},
+		}),
+	}
+	for _, s := range data.Schemes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-security-client",
+			Source: securityClientT,
+			Data:   map[string]interface{}{"ServiceName": data.ServiceName, "Scheme": s},
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// buildSecurityData returns the security data of the endpoints listed in the
+// given service data.
+func buildSecurityData(svc *httpcodegen.ServiceData) *securityData {
+	data := &securityData{ServiceName: svc.Service.Name}
+	schemes := make(map[*secdesign.SchemeExpr]*securitySchemeData)
+	for _, e := range svc.Endpoints {
+		reqs := secdesign.Requirements(svc.Service.Name, e.Method.Name)
+		if len(reqs) == 0 {
+			continue
+		}
+		ed := &securityEndpointData{
+			ServiceName: svc.Service.Name,
+			MethodName:  e.Method.Name,
+			VarName:     e.Method.VarName,
+		}
+		seen := make(map[string]bool)
+		for _, r := range reqs {
+			var req []string
+			for _, s := range r.Schemes {
+				sd, ok := schemes[s]
+				if !ok {
+					sd = buildSecuritySchemeData(s)
+					schemes[s] = sd
+				}
+				req = append(req, schemeMiddleware(sd, s, r))
+				if sd.ServerBefore != "" && !seen[sd.ServerBefore] {
+					seen[sd.ServerBefore] = true
+					ed.ServerBefore = append(ed.ServerBefore, sd.ServerBefore)
+				}
+			}
+			ed.Requirements = append(ed.Requirements, req)
+		}
+		data.Endpoints = append(data.Endpoints, ed)
+	}
+	for _, s := range secdesign.Root.Schemes {
+		if sd, ok := schemes[s]; ok {
+			data.Schemes = append(data.Schemes, sd)
+		}
+	}
+	return data
+}
+
+// buildSecuritySchemeData returns the go-kit code of the given scheme. The
+// basic auth and JWT schemes are authorized with user provided go-kit
+// middlewares such as basic.AuthMiddleware and kitjwt.NewParser, the API key
+// and OAuth2 schemes with user provided functions that validate the API keys
+// and the access tokens.
+func buildSecuritySchemeData(s *secdesign.SchemeExpr) *securitySchemeData {
+	sd := &securitySchemeData{
+		Name:    s.SchemeName,
+		VarName: codegen.Goify(s.SchemeName, true),
+		Kind:    s.Kind.String(),
+	}
+	in, key := s.In, s.Key
+	if s.Kind == secdesign.JWTKind && key == "" {
+		in, key = "header", "Authorization"
+	}
+	switch s.Kind {
+	case secdesign.BasicAuthKind:
+		sd.AuthorizerType = "endpoint.Middleware"
+		sd.Authorizer = "a." + sd.VarName
+		sd.ServerBefore = "kithttp.PopulateRequestContext"
+		sd.ClientParams = "user, pass string"
+		sd.ClientBefore = "kitauth.BasicAuthToHTTP(user, pass)"
+	case secdesign.JWTKind:
+		sd.AuthorizerType = "endpoint.Middleware"
+		sd.Authorizer = "a." + sd.VarName
+		if in == "header" && key == "Authorization" {
+			sd.ServerBefore = "kitjwt.HTTPToContext()"
+			sd.ClientBefore = "kitjwt.ContextToHTTP()"
+		} else {
+			sd.ServerBefore = fmt.Sprintf("kitauth.JWTToContext(%q, %q)", in, key)
+			sd.ClientBefore = fmt.Sprintf("kitauth.JWTToHTTP(%q, %q)", in, key)
+		}
+	case secdesign.APIKeyKind:
+		sd.AuthorizerType = "kitauth.CredentialsFunc"
+		sd.Authorizer = fmt.Sprintf("kitauth.APIKey(%q, a.%s)", sd.Name, sd.VarName)
+		sd.ServerBefore = fmt.Sprintf("kitauth.APIKeyToContext(%q, %q, %q)", sd.Name, in, key)
+		sd.ClientParams = "key string"
+		sd.ClientBefore = fmt.Sprintf("kitauth.APIKeyToHTTP(%q, %q, key)", in, key)
+	case secdesign.OAuth2Kind:
+		sd.AuthorizerType = "kitauth.CredentialsFunc"
+		sd.Authorizer = fmt.Sprintf("kitauth.OAuth2(%q, a.%s)", sd.Name, sd.VarName)
+		sd.ServerBefore = fmt.Sprintf("kitauth.OAuth2ToContext(%q)", sd.Name)
+		sd.ClientParams = "token string"
+		sd.ClientBefore = "kitauth.OAuth2ToHTTP(token)"
+	}
+	return sd
+}
+
+// schemeMiddleware returns the expression that builds the middleware that
+// authorizes the requests secured with the given scheme as part of the given
+// requirement. The middleware checks the requirement scopes defined by the
+// scheme.
+func schemeMiddleware(sd *securitySchemeData, s *secdesign.SchemeExpr, r *secdesign.SecurityExpr) string {
+	args := []string{fmt.Sprintf("%q", sd.Name), sd.Authorizer}
+	if s.HasScopes() {
+		for _, sc := range r.Scopes {
+			if s.Scope(sc) != nil {
+				args = append(args, fmt.Sprintf("%q", sc))
+			}
+		}
+	}
+	return fmt.Sprintf("kitauth.Scheme(%s)", strings.Join(args, ", "))
+}
+
+// input: securityData
+const securityAuthorizersT = `{{ printf "Authorizers holds the go-kit middlewares and functions that authorize the requests made to the secured %s service endpoints. The requirements that use a scheme whose authorizer is nil always fail. The scopes required by the requirements are checked against the scopes returned by kitauth.Scopes." .ServiceName | comment }}
+type Authorizers struct {
+{{- range .Schemes }}
+	{{- if eq .Kind "BasicAuth" }}
+	{{ printf "%s authorizes the requests secured with the %q basic auth scheme, for example with basic.AuthMiddleware. The credentials are read by kithttp.PopulateRequestContext." .VarName .Name | comment }}
+	{{- else if eq .Kind "JWT" }}
+	{{ printf "%s authorizes the requests secured with the %q JWT scheme, for example with kitjwt.NewParser. The token is stored in the context under the kitjwt.JWTTokenContextKey key." .VarName .Name | comment }}
+	{{- else if eq .Kind "APIKey" }}
+	{{ printf "%s validates the API keys of the requests secured with the %q API key scheme." .VarName .Name | comment }}
+	{{- else }}
+	{{ printf "%s validates the access tokens of the requests secured with the %q OAuth2 scheme. It may record the scopes granted to the token with kitauth.WithScopes." .VarName .Name | comment }}
+	{{- end }}
+	{{ .VarName }} {{ .AuthorizerType }}
+{{- end }}
+}
+
+{{ printf "SecurityMiddleware returns a function that builds the middlewares that authorize the requests made to the secured %s service endpoints with the authorizers a. The function is suitable for the Apply method of the service Endpoints struct, it returns nil for the endpoints that are not secured." .ServiceName | comment }}
+func SecurityMiddleware(a *Authorizers) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		switch method {
+{{- range .Endpoints }}
+		case {{ printf "%q" .MethodName }}:
+			return {{ .VarName }}SecurityMiddleware(a)
+{{- end }}
+		}
+		return nil
+	}
+}
+`
+
+// input: securityEndpointData
+const securityServerT = `{{ printf "%sSecurityServerBefore returns a go-kit HTTP server option that reads the credentials of the requests made to the %s service %s endpoint. The credentials are validated by the middleware returned by %sSecurityMiddleware." .VarName .ServiceName .MethodName .VarName | comment }}
+func {{ .VarName }}SecurityServerBefore() kithttp.ServerOption {
+	return kithttp.ServerBefore(
+{{- range .ServerBefore }}
+		{{ . }},
+{{- end }}
+	)
+}
+
+{{ printf "%sSecurityMiddleware returns a go-kit middleware that authorizes the requests made to the %s service %s endpoint with the authorizers a. The requests are authorized if they satisfy any of the security requirements of the method." .VarName .ServiceName .MethodName | comment }}
+func {{ .VarName }}SecurityMiddleware(a *Authorizers) endpoint.Middleware {
+	return kitauth.Any(
+{{- range .Requirements }}
+		kitauth.All(
+	{{- range . }}
+			{{ . }},
+	{{- end }}
+		),
+{{- end }}
+	)
+}
+`
+
+// input: map[string]interface{}{"ServiceName": string, "Scheme": securitySchemeData}
+const securityClientT = `{{ if eq .Scheme.Kind "JWT" -}}
+{{ printf "%sClientBefore returns a go-kit HTTP client option that writes the token of the %q JWT scheme in the requests made to the %s service. The token is read from the context under the kitjwt.JWTTokenContextKey key, for example as set by the kitjwt.NewSigner middleware." .Scheme.VarName .Scheme.Name .ServiceName | comment }}
+{{- else if eq .Scheme.Kind "BasicAuth" -}}
+{{ printf "%sClientBefore returns a go-kit HTTP client option that sets the basic auth credentials of the %q scheme in the requests made to the %s service." .Scheme.VarName .Scheme.Name .ServiceName | comment }}
+{{- else if eq .Scheme.Kind "OAuth2" -}}
+{{ printf "%sClientBefore returns a go-kit HTTP client option that writes the given access token of the %q OAuth2 scheme in the requests made to the %s service." .Scheme.VarName .Scheme.Name .ServiceName | comment }}
+{{- else -}}
+{{ printf "%sClientBefore returns a go-kit HTTP client option that writes the given key of the %q API key scheme in the requests made to the %s service." .Scheme.VarName .Scheme.Name .ServiceName | comment }}
+{{- end }}
+func {{ .Scheme.VarName }}ClientBefore({{ .Scheme.ClientParams }}) kithttp.ClientOption {
+	return kithttp.ClientBefore({{ .Scheme.ClientBefore }})
+}
+`
@@ -0,0 +1,42 @@
+package goakit
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestSecurityFiles(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SecurityDSL)
+	fs := SecurityFiles("", httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	dir := filepath.Join(codegen.Gendir, "http", "secured_service")
+	if path := filepath.Join(dir, "kitserver", "security.go"); fs[0].Path != path {
+		t.Errorf("got path %q, expected %q", fs[0].Path, path)
+	}
+	if path := filepath.Join(dir, "kitclient", "security.go"); fs[1].Path != path {
+		t.Errorf("got path %q, expected %q", fs[1].Path, path)
+	}
+	testCode(t, fs[0], "goakit-security-authorizers", []string{testdata.SecurityAuthorizersCode})
+	testCode(t, fs[0], "goakit-security-server", []string{testdata.SecureMethodSecurityServerCode, testdata.MultiMethodSecurityServerCode})
+	testCode(t, fs[1], "goakit-security-client", []string{
+		testdata.BasicSecurityClientCode,
+		testdata.TokenSecurityClientCode,
+		testdata.HeaderTokenSecurityClientCode,
+		testdata.APIKeySecurityClientCode,
+		testdata.OAuth2SecurityClientCode,
+	})
+}
+
+func TestSecurityFilesNoSecurity(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SimpleServiceDSL)
+	if fs := SecurityFiles("", httpdesign.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
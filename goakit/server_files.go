@@ -0,0 +1,105 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ServerFiles produces the files containing the go-kit HTTP server
+// constructors that wire the generated request decoders, response encoders
+// and error encoders. The constructors of the endpoints that use multipart
+// requests accept the user provided multipart decoder function instead of the
+// request decoder.
+func ServerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, len(root.HTTPServices))
+	for i, svc := range root.HTTPServices {
+		fw[i] = serverFile(genpkg, svc)
+	}
+	return fw
+}
+
+// serverFile returns the file defining the go-kit HTTP server constructors for
+// the given service.
+func serverFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitserver", "server.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit HTTP server", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "net/http"},
+			{Path: "github.com/go-kit/kit/endpoint"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: genpkg + "/http/" + data.Service.Name + "/server"},
+		}),
+	}
+	fm := codegen.TemplateFuncs()
+	fm["serverComment"] = serverComment
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "goakit-server",
+			Source:  serverT,
+			Data:    e,
+			FuncMap: fm,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// kitServiceData returns a copy of the given service data that only lists the
+// endpoints go-kit can serve. The go-kit request/response model cannot express
+// the streaming endpoints, the goa generated WebSocket handlers serve them
+// instead.
+func kitServiceData(data *httpcodegen.ServiceData) *httpcodegen.ServiceData {
+	kd := *data
+	kd.Endpoints = nil
+	for _, e := range data.Endpoints {
+		if e.ServerStream == nil {
+			kd.Endpoints = append(kd.Endpoints, e)
+		}
+	}
+	return &kd
+}
+
+// serverComment returns the comment of the go-kit HTTP server constructor of
+// the given endpoint.
+func serverComment(e *httpcodegen.EndpointData) string {
+	c := fmt.Sprintf("New%sServer returns a go-kit HTTP server that serves the %s service %s endpoint.",
+		e.Method.VarName, e.ServiceName, e.Method.Name)
+	if e.MultipartRequestDecoder != nil {
+		c += fmt.Sprintf(" The multipart requests are decoded with the user provided function %s.",
+			e.MultipartRequestDecoder.VarName)
+	}
+	if len(e.Errors) > 0 {
+		c += fmt.Sprintf(" The errors defined in the design are encoded with %s so that the responses use the designed status codes, the given options may override the error encoder.",
+			e.ErrorEncoder)
+	}
+	return c
+}
+
+// input: EndpointData
+const serverT = `{{ serverComment . | comment }}
+func New{{ .Method.VarName }}Server(e endpoint.Endpoint, mux goahttp.Muxer, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.VarName }} server.{{ .MultipartRequestDecoder.FuncName }}{{ else }}dec func(*http.Request) goahttp.Decoder{{ end }}, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+{{- if .Errors }}
+	opts = append([]kithttp.ServerOption{kithttp.ServerErrorEncoder({{ .ErrorEncoder }}(enc))}, opts...)
+{{- end }}
+	return kithttp.NewServer(
+		e,
+{{- if .MultipartRequestDecoder }}
+		{{ .RequestDecoder }}(mux, {{ .MultipartRequestDecoder.VarName }}),
+{{- else if .Payload.Ref }}
+		{{ .RequestDecoder }}(mux, dec),
+{{- else }}
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+{{- end }}
+		{{ .ResponseEncoder }}(enc),
+		opts...,
+	)
+}
+`
@@ -0,0 +1,33 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestServerFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL  func()
+		Code []string
+	}{
+		"simple-service":  {testdata.SimpleServiceDSL, []string{testdata.SimpleMethodGoakitServerCode}},
+		"with-payload":    {testdata.WithPayloadDSL, []string{testdata.WithPayloadMethodGoakitServerCode}},
+		"with-error":      {testdata.WithErrorDSL, []string{testdata.WithErrorMethodGoakitServerCode}},
+		"multi-endpoints": {testdata.MultiEndpointDSL, []string{testdata.Endpoint1GoakitServerCode, testdata.Endpoint2GoakitServerCode}},
+		"multipart":       {testdata.MultipartDSL, []string{testdata.MultipartMethodGoakitServerCode}},
+		"streaming":       {testdata.StreamingDSL, []string{testdata.UnaryMethodGoakitServerCode}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected 1", len(fs))
+			}
+			testCode(t, fs[0], "goakit-server", c.Code)
+		})
+	}
+}
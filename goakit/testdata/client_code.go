@@ -0,0 +1,133 @@
+package testdata
+
+var SimpleServiceGoakitClientNewCode = `// New returns the SimpleService service endpoints implemented with go-kit HTTP
+// clients that make requests to the given host using the given scheme. The
+// endpoints apply the client timeouts, retries and circuit breakers defined in
+// the design.
+func New(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *simpleservice.Endpoints {
+	return &simpleservice.Endpoints{
+		SimpleMethod: NewSimpleMethodClient(scheme, host, enc, dec, opts...).Endpoint(),
+	}
+}
+`
+
+var SimpleMethodGoakitClientCode = `// NewSimpleMethodClient returns a go-kit HTTP client that makes requests to
+// the SimpleService service SimpleMethod endpoint. The request path is built
+// from the payload so that it includes the path parameters.
+func NewSimpleMethodClient(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *kithttp.Client {
+	c := client.NewClient(scheme, host, nil, enc, dec, false)
+	return kithttp.NewClient(
+		"GET",
+		&url.URL{Scheme: scheme, Host: host},
+		func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := c.BuildSimpleMethodRequest(ctx, v)
+			if err != nil {
+				return err
+			}
+			r.Method = req.Method
+			r.URL = req.URL
+			r.Host = req.Host
+			return nil
+		},
+		DecodeSimpleMethodResponse(dec),
+		opts...,
+	)
+}
+`
+
+var WithPayloadServiceGoakitClientNewCode = `// New returns the WithPayloadService service endpoints implemented with go-kit
+// HTTP clients that make requests to the given host using the given scheme.
+// The endpoints apply the client timeouts, retries and circuit breakers
+// defined in the design.
+func New(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *withpayloadservice.Endpoints {
+	return &withpayloadservice.Endpoints{
+		WithPayloadMethod: NewWithPayloadMethodClient(scheme, host, enc, dec, opts...).Endpoint(),
+	}
+}
+`
+
+var WithPayloadMethodGoakitClientCode = `// NewWithPayloadMethodClient returns a go-kit HTTP client that makes requests
+// to the WithPayloadService service WithPayloadMethod endpoint. The request
+// path is built from the payload so that it includes the path parameters.
+func NewWithPayloadMethodClient(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *kithttp.Client {
+	c := client.NewClient(scheme, host, nil, enc, dec, false)
+	encode := EncodeWithPayloadMethodRequest(enc)
+	return kithttp.NewClient(
+		"GET",
+		&url.URL{Scheme: scheme, Host: host},
+		func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := c.BuildWithPayloadMethodRequest(ctx, v)
+			if err != nil {
+				return err
+			}
+			r.Method = req.Method
+			r.URL = req.URL
+			r.Host = req.Host
+			return encode(ctx, r, v)
+		},
+		DecodeWithPayloadMethodResponse(dec),
+		opts...,
+	)
+}
+`
+
+var MultiEndpointServiceGoakitClientNewCode = `// New returns the MultiEndpointService service endpoints implemented with
+// go-kit HTTP clients that make requests to the given host using the given
+// scheme. The endpoints apply the client timeouts, retries and circuit
+// breakers defined in the design.
+func New(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *multiendpointservice.Endpoints {
+	return &multiendpointservice.Endpoints{
+		Endpoint1: NewEndpoint1Client(scheme, host, enc, dec, opts...).Endpoint(),
+		Endpoint2: NewEndpoint2Client(scheme, host, enc, dec, opts...).Endpoint(),
+	}
+}
+`
+
+var Endpoint1GoakitClientCode = `// NewEndpoint1Client returns a go-kit HTTP client that makes requests to the
+// MultiEndpointService service Endpoint1 endpoint. The request path is built
+// from the payload so that it includes the path parameters.
+func NewEndpoint1Client(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *kithttp.Client {
+	c := client.NewClient(scheme, host, nil, enc, dec, false)
+	encode := EncodeEndpoint1Request(enc)
+	return kithttp.NewClient(
+		"GET",
+		&url.URL{Scheme: scheme, Host: host},
+		func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := c.BuildEndpoint1Request(ctx, v)
+			if err != nil {
+				return err
+			}
+			r.Method = req.Method
+			r.URL = req.URL
+			r.Host = req.Host
+			return encode(ctx, r, v)
+		},
+		DecodeEndpoint1Response(dec),
+		opts...,
+	)
+}
+`
+
+var Endpoint2GoakitClientCode = `// NewEndpoint2Client returns a go-kit HTTP client that makes requests to the
+// MultiEndpointService service Endpoint2 endpoint. The request path is built
+// from the payload so that it includes the path parameters.
+func NewEndpoint2Client(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *kithttp.Client {
+	c := client.NewClient(scheme, host, nil, enc, dec, false)
+	return kithttp.NewClient(
+		"POST",
+		&url.URL{Scheme: scheme, Host: host},
+		func(ctx context.Context, r *http.Request, v interface{}) error {
+			req, err := c.BuildEndpoint2Request(ctx, v)
+			if err != nil {
+				return err
+			}
+			r.Method = req.Method
+			r.URL = req.URL
+			r.Host = req.Host
+			return nil
+		},
+		DecodeEndpoint2Response(dec),
+		opts...,
+	)
+}
+`
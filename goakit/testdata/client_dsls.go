@@ -0,0 +1,51 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/http/design"
+	. "goa.design/plugins/goakit/dsl"
+)
+
+var ClientSettingsDSL = func() {
+	Service("ClientService", func() {
+		Method("TimeoutMethod", func() {
+			Timeout(2 * time.Second)
+			HTTP(func() {
+				POST("/timeout")
+			})
+		})
+		Method("RetryMethod", func() {
+			Idempotent()
+			Retry(2)
+			HTTP(func() {
+				GET("/retry")
+			})
+		})
+		Method("GobreakerMethod", func() {
+			Idempotent()
+			Timeout(500 * time.Millisecond)
+			Retry(1)
+			CircuitBreaker("gobreaker")
+			HTTP(func() {
+				GET("/gobreaker")
+			})
+		})
+		Method("HystrixMethod", func() {
+			CircuitBreaker("hystrix", func() {
+				ErrorThreshold(25)
+				VolumeThreshold(10)
+				SleepWindow(time.Minute)
+			})
+			HTTP(func() {
+				POST("/hystrix")
+			})
+		})
+		Method("IdempotentMethod", func() {
+			Idempotent()
+			HTTP(func() {
+				GET("/idempotent")
+			})
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package testdata
+
+var TimeoutMethodClientMiddlewareCode = `// TimeoutMethodMiddleware returns a go-kit endpoint middleware that applies
+// the client timeout, retries and circuit breaker defined in the design of the
+// ClientService service TimeoutMethod method.
+func TimeoutMethodMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			return next(ctx, req)
+		}
+	}
+}
+`
+
+var RetryMethodClientMiddlewareCode = `// RetryMethodMiddleware returns a go-kit endpoint middleware that applies the
+// client timeout, retries and circuit breaker defined in the design of the
+// ClientService service RetryMethod method.
+func RetryMethodMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return lb.Retry(3, 10*time.Second, lb.NewRoundRobin(sd.FixedEndpointer{next}))
+	}
+}
+`
+
+var GobreakerMethodClientMiddlewareCode = `// GobreakerMethodMiddleware returns a go-kit endpoint middleware that applies
+// the client timeout, retries and circuit breaker defined in the design of the
+// ClientService service GobreakerMethod method.
+func GobreakerMethodMiddleware() endpoint.Middleware {
+	breaker := GobreakerMethodBreaker()
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		next = breaker(next)
+		return lb.Retry(2, 500*time.Millisecond, lb.NewRoundRobin(sd.FixedEndpointer{next}))
+	}
+}
+`
+
+var HystrixMethodClientMiddlewareCode = `// HystrixMethodMiddleware returns a go-kit endpoint middleware that applies
+// the client timeout, retries and circuit breaker defined in the design of the
+// ClientService service HystrixMethod method.
+func HystrixMethodMiddleware() endpoint.Middleware {
+	breaker := HystrixMethodBreaker()
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return breaker(next)
+	}
+}
+`
+
+var GobreakerMethodClientBreakerCode = `// GobreakerMethodBreaker returns the go-kit circuit breaker middleware defined
+// in the design of the ClientService service GobreakerMethod method.
+func GobreakerMethodBreaker() endpoint.Middleware {
+	return circuitbreaker.Gobreaker(gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:    "ClientService.GobreakerMethod",
+		Timeout: 5 * time.Second,
+		ReadyToTrip: func(c gobreaker.Counts) bool {
+			return c.Requests >= 20 && c.TotalFailures*100 >= 50*c.Requests
+		},
+	}))
+}
+`
+
+var HystrixMethodClientBreakerCode = `// HystrixMethodBreaker returns the go-kit circuit breaker middleware defined
+// in the design of the ClientService service HystrixMethod method.
+func HystrixMethodBreaker() endpoint.Middleware {
+	hystrix.ConfigureCommand("ClientService.HystrixMethod", hystrix.CommandConfig{
+		Timeout:                10000,
+		ErrorPercentThreshold:  25,
+		RequestVolumeThreshold: 10,
+		SleepWindow:            60000,
+	})
+	return circuitbreaker.Hystrix("ClientService.HystrixMethod")
+}
+`
+
+var ClientServiceGoakitClientNewCode = `// New returns the ClientService service endpoints implemented with go-kit HTTP
+// clients that make requests to the given host using the given scheme. The
+// endpoints apply the client timeouts, retries and circuit breakers defined in
+// the design.
+func New(scheme, host string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) *clientservice.Endpoints {
+	return &clientservice.Endpoints{
+		TimeoutMethod:    TimeoutMethodMiddleware()(NewTimeoutMethodClient(scheme, host, enc, dec, opts...).Endpoint()),
+		RetryMethod:      RetryMethodMiddleware()(NewRetryMethodClient(scheme, host, enc, dec, opts...).Endpoint()),
+		GobreakerMethod:  GobreakerMethodMiddleware()(NewGobreakerMethodClient(scheme, host, enc, dec, opts...).Endpoint()),
+		HystrixMethod:    HystrixMethodMiddleware()(NewHystrixMethodClient(scheme, host, enc, dec, opts...).Endpoint()),
+		IdempotentMethod: NewIdempotentMethodClient(scheme, host, enc, dec, opts...).Endpoint(),
+	}
+}
+`
@@ -59,29 +59,42 @@ func DecodeEndpoint1Request(mux goahttp.Muxer, decoder func(*http.Request) goaht
 
 var WithErrorMethodGoakitErrorEncoderCode = `// EncodeWithErrorMethodError returns a go-kit EncodeResponseFunc suitable for
 // encoding errors returned by the WithErrorService WithErrorMethod endpoint.
+// The errors wrapped with NewWithErrorMethodError are unwrapped before being
+// encoded.
 func EncodeWithErrorMethodError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
 	enc := server.EncodeWithErrorMethodError(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
 `
 
 var Endpoint1GoakitErrorEncoderCode = `// EncodeEndpoint1Error returns a go-kit EncodeResponseFunc suitable for
-// encoding errors returned by the MultiEndpointService Endpoint1 endpoint.
+// encoding errors returned by the MultiEndpointService Endpoint1 endpoint. The
+// errors wrapped with NewEndpoint1Error are unwrapped before being encoded.
 func EncodeEndpoint1Error(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
 	enc := server.EncodeEndpoint1Error(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
 `
 
 var Endpoint2GoakitErrorEncoderCode = `// EncodeEndpoint2Error returns a go-kit EncodeResponseFunc suitable for
-// encoding errors returned by the MultiEndpointService Endpoint2 endpoint.
+// encoding errors returned by the MultiEndpointService Endpoint2 endpoint. The
+// errors wrapped with NewEndpoint2Error are unwrapped before being encoded.
 func EncodeEndpoint2Error(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
 	enc := server.EncodeEndpoint2Error(encoder)
 	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
 		enc(ctx, w, err)
 	}
 }
@@ -219,3 +232,51 @@ func MountMixedFileJSON(mux goahttp.Muxer) {
 	}))
 }
 `
+
+var MultipartMethodGoakitRequestDecoderCode = `// DecodeMultipartMethodRequest returns a go-kit DecodeRequestFunc suitable for
+// decoding MultipartService MultipartMethod multipart requests. The request
+// parts are decoded with the user provided function
+// multipartServiceMultipartMethodDecoderFn.
+func DecodeMultipartMethodRequest(mux goahttp.Muxer, multipartServiceMultipartMethodDecoderFn server.MultipartServiceMultipartMethodDecoderFunc) kithttp.DecodeRequestFunc {
+	dec := server.DecodeMultipartMethodRequest(mux, server.NewMultipartServiceMultipartMethodDecoder(mux, multipartServiceMultipartMethodDecoderFn))
+	return func(ctx context.Context, r *http.Request) (interface{}, error) {
+		r = r.WithContext(ctx)
+		return dec(r)
+	}
+}
+`
+
+var MultipartMethodGoakitResponseEncoderCode = `// EncodeMultipartMethodResponse returns a go-kit EncodeResponseFunc suitable
+// for encoding MultipartService MultipartMethod responses.
+func EncodeMultipartMethodResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.EncodeResponseFunc {
+	return server.EncodeMultipartMethodResponse(encoder)
+}
+`
+
+var StreamingMethodGoakitMountCode = `// MountStreamingMethodHandler configures the mux to serve the
+// "StreamingService" service "StreamingMethod" endpoint. goakit generates no
+// go-kit transport for the streaming endpoint, h must be the WebSocket handler
+// built by the goa generated server.
+func MountStreamingMethodHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/stream", f)
+}
+`
+
+var UnaryMethodGoakitMountCode = `// MountUnaryMethodHandler configures the mux to serve the "StreamingService"
+// service "UnaryMethod" endpoint.
+func MountUnaryMethodHandler(mux goahttp.Muxer, h http.Handler) {
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("GET", "/", f)
+}
+`
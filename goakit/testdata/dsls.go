@@ -28,6 +28,18 @@ var WithPayloadDSL = func() {
 	})
 }
 
+var WithResultDSL = func() {
+	Service("WithResultService", func() {
+		Method("WithResultMethod", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var WithErrorDSL = func() {
 	Service("WithErrorService", func() {
 		Method("WithErrorMethod", func() {
@@ -95,3 +107,70 @@ var MultiServiceDSL = func() {
 		})
 	})
 }
+
+var JSONRPCDSL = func() {
+	Service("RPCService", func() {
+		Method("ParamsMethod", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("tags", ArrayOf(String))
+				Attribute("name", String)
+			})
+			Result(String)
+			HTTP(func() {
+				PUT("/items/{id}")
+				Param("tags")
+				Response(StatusCreated)
+			})
+		})
+		Method("NoPayloadMethod", func() {
+			HTTP(func() {
+				GET("/status")
+			})
+		})
+	})
+}
+
+var MultipartDSL = func() {
+	Service("MultipartService", func() {
+		Method("MultipartMethod", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("content", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var StreamingDSL = func() {
+	Service("StreamingService", func() {
+		Method("StreamingMethod", func() {
+			Payload(String)
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/stream")
+				Param("p")
+			})
+		})
+		Method("UnaryMethod", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var MetricsDSL = func() {
+	API("calc-api", func() {})
+	Service("MetricsService", func() {
+		Method("MetricsMethod", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
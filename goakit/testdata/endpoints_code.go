@@ -0,0 +1,42 @@
+package testdata
+
+var SimpleServiceEndpointsApplyCode = `// Apply applies the middlewares returned by m to the "SimpleService" service
+// endpoints. m is called with the name of the method implemented by each
+// endpoint as defined in the design. The endpoints for which m returns nil are
+// left unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("SimpleMethod"); mw != nil {
+		e.SimpleMethod = mw(e.SimpleMethod)
+	}
+}
+`
+
+var MultiEndpointServiceEndpointsApplyCode = `// Apply applies the middlewares returned by m to the "MultiEndpointService"
+// service endpoints. m is called with the name of the method implemented by
+// each endpoint as defined in the design. The endpoints for which m returns
+// nil are left unchanged.
+func (e *Endpoints) Apply(m func(method string) endpoint.Middleware) {
+	if mw := m("Endpoint1"); mw != nil {
+		e.Endpoint1 = mw(e.Endpoint1)
+	}
+	if mw := m("Endpoint2"); mw != nil {
+		e.Endpoint2 = mw(e.Endpoint2)
+	}
+}
+`
+
+var SimpleServiceEndpointsUseGoaCode = `// UseGoa applies the goa endpoint middleware m to the "SimpleService" service
+// endpoints so that the middlewares written for the services generated without
+// goakit may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+`
+
+var MultiEndpointServiceEndpointsUseGoaCode = `// UseGoa applies the goa endpoint middleware m to the "MultiEndpointService"
+// service endpoints so that the middlewares written for the services generated
+// without goakit may be reused.
+func (e *Endpoints) UseGoa(m kitendpoint.GoaMiddleware) {
+	e.Use(kitendpoint.FromGoaMiddleware(m))
+}
+`
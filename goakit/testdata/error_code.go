@@ -0,0 +1,152 @@
+package testdata
+
+var WithErrorServiceGoakitErrorCode = `// Error wraps an error defined in the design of the WithErrorService service
+// so that it implements the go-kit kithttp.StatusCoder and kithttp.Headerer
+// interfaces as well as json.Marshaler. kithttp.DefaultErrorEncoder uses these
+// interfaces to write the status code, headers and body defined in the design.
+type Error struct {
+	// Name is the name of the error as defined in the design.
+	Name string
+	// Status is the HTTP status code of the error response.
+	Status int
+	// Err is the wrapped error.
+	Err error
+	// body is the error response body, nil if the design does not define
+	// one.
+	body interface{}
+}
+
+// Error returns the message of the wrapped error.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// ErrorName returns the name of the error as defined in the design.
+func (e *Error) ErrorName() string {
+	return e.Name
+}
+
+// StatusCode returns the HTTP status code of the error response.
+func (e *Error) StatusCode() int {
+	return e.Status
+}
+
+// Headers returns the headers of the error response.
+func (e *Error) Headers() http.Header {
+	return http.Header{"goa-error": []string{e.Name}}
+}
+
+// MarshalJSON returns the JSON encoding of the error response body. The
+// wrapped error is encoded if the design does not define a body.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e.body == nil {
+		return json.Marshal(e.Err)
+	}
+	return json.Marshal(e.body)
+}
+`
+
+var WithErrorMethodGoakitErrorWrapperCode = `// NewWithErrorMethodError wraps err into an *Error if it is one of the errors
+// defined in the design of the WithErrorService service WithErrorMethod
+// method. It returns err unchanged otherwise.
+func NewWithErrorMethodError(err error) error {
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	en, ok := err.(server.ErrorNamer)
+	if !ok {
+		return err
+	}
+	switch en.ErrorName() {
+	case "bad_request":
+		e := &Error{Name: "bad_request", Status: http.StatusBadRequest, Err: err}
+		e.body = server.NewWithErrorMethodBadRequestResponseBody(err.(*goa.ServiceError))
+		return e
+	}
+	return err
+}
+
+// WithErrorMethodErrorMiddleware is a go-kit endpoint middleware that wraps
+// the errors returned by the WithErrorService service WithErrorMethod endpoint
+// with NewWithErrorMethodError. It should be the innermost middleware so that
+// the other middlewares observe the wrapped errors.
+func WithErrorMethodErrorMiddleware(e endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := e(ctx, req)
+		if err != nil {
+			return nil, NewWithErrorMethodError(err)
+		}
+		return res, nil
+	}
+}
+`
+
+var Endpoint1GoakitErrorWrapperCode = `// NewEndpoint1Error wraps err into an *Error if it is one of the errors
+// defined in the design of the MultiEndpointService service Endpoint1 method.
+// It returns err unchanged otherwise.
+func NewEndpoint1Error(err error) error {
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	en, ok := err.(server.ErrorNamer)
+	if !ok {
+		return err
+	}
+	switch en.ErrorName() {
+	case "bad_request":
+		e := &Error{Name: "bad_request", Status: http.StatusBadRequest, Err: err}
+		e.body = server.NewEndpoint1BadRequestResponseBody(err.(*goa.ServiceError))
+		return e
+	}
+	return err
+}
+
+// Endpoint1ErrorMiddleware is a go-kit endpoint middleware that wraps the
+// errors returned by the MultiEndpointService service Endpoint1 endpoint with
+// NewEndpoint1Error. It should be the innermost middleware so that the other
+// middlewares observe the wrapped errors.
+func Endpoint1ErrorMiddleware(e endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := e(ctx, req)
+		if err != nil {
+			return nil, NewEndpoint1Error(err)
+		}
+		return res, nil
+	}
+}
+`
+
+var Endpoint2GoakitErrorWrapperCode = `// NewEndpoint2Error wraps err into an *Error if it is one of the errors
+// defined in the design of the MultiEndpointService service Endpoint2 method.
+// It returns err unchanged otherwise.
+func NewEndpoint2Error(err error) error {
+	if _, ok := err.(*Error); ok {
+		return err
+	}
+	en, ok := err.(server.ErrorNamer)
+	if !ok {
+		return err
+	}
+	switch en.ErrorName() {
+	case "bad_request":
+		e := &Error{Name: "bad_request", Status: http.StatusBadRequest, Err: err}
+		e.body = server.NewEndpoint2BadRequestResponseBody(err.(*goa.ServiceError))
+		return e
+	}
+	return err
+}
+
+// Endpoint2ErrorMiddleware is a go-kit endpoint middleware that wraps the
+// errors returned by the MultiEndpointService service Endpoint2 endpoint with
+// NewEndpoint2Error. It should be the innermost middleware so that the other
+// middlewares observe the wrapped errors.
+func Endpoint2ErrorMiddleware(e endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, err := e(ctx, req)
+		if err != nil {
+			return nil, NewEndpoint2Error(err)
+		}
+		return res, nil
+	}
+}
+`
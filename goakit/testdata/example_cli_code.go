@@ -0,0 +1,35 @@
+package testdata
+
+var MultiEndpointCLIEndpointCode = `// kitEndpoint returns the go-kit HTTP client endpoint of the given service
+// method. The clients make the requests using the given doer.
+func kitEndpoint(svc, method, scheme, host string, doer goahttp.Doer) (endpoint.Endpoint, error) {
+	opts := []kithttp.ClientOption{kithttp.SetClient(doer)}
+	switch svc {
+	case "multi-endpoint-service":
+		e := multiendpointservicekc.New(scheme, host, goahttp.RequestEncoder, goahttp.ResponseDecoder, opts...)
+		switch method {
+		case "endpoint1":
+			return e.Endpoint1, nil
+		case "endpoint2":
+			return e.Endpoint2, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %q endpoint %q", svc, method)
+}
+`
+
+var StreamingCLIEndpointCode = `// kitEndpoint returns the go-kit HTTP client endpoint of the given service
+// method. The clients make the requests using the given doer.
+func kitEndpoint(svc, method, scheme, host string, doer goahttp.Doer) (endpoint.Endpoint, error) {
+	opts := []kithttp.ClientOption{kithttp.SetClient(doer)}
+	switch svc {
+	case "streaming-service":
+		e := streamingservicekc.New(scheme, host, goahttp.RequestEncoder, goahttp.ResponseDecoder, opts...)
+		switch method {
+		case "unary-method":
+			return e.UnaryMethod, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %q endpoint %q", svc, method)
+}
+`
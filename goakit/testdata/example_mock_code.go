@@ -0,0 +1,47 @@
+package testdata
+
+var MockServiceMockStructCode = `// MockService service mock implementation.
+// The mock methods return the examples defined in the design or random values
+// that honor the design validations. They return the method error whose name
+// is given in the X-Mock-Error request header instead when the header is set.
+type mockServiceMock struct {
+	logger log.Logger
+}
+
+// NewMockServiceMock returns the MockService service mock implementation.
+func NewMockServiceMock(logger log.Logger) mockservice.Service {
+	return &mockServiceMock{logger}
+}
+`
+
+var ResultMethodMockCode = `// ResultMethod returns an item.
+func (s *mockServiceMock) ResultMethod(ctx context.Context, p *mockservice.ResultMethodPayload) (*mockservice.Item, error) {
+	var res *mockservice.Item
+	s.logger.Log("msg", "mockService.ResultMethod", "mock", true)
+	switch name := kitmock.Error(ctx); name {
+	case "":
+		res = &mockservice.Item{Href: "/items/1", Count: 3, Tags: []string{"a", "b"}, Note: kitmock.String("note"), Owner: &struct {
+			Name *string
+		}{Name: kitmock.String("joe")}}
+	case "bad_request":
+		return res, mockservice.MakeBadRequest(kitmock.NewError("bad_request"))
+	default:
+		return res, kitmock.UnknownError(name)
+	}
+	return res, nil
+}
+`
+
+var NoResultMethodMockCode = `// NoResultMethod has no result.
+func (s *mockServiceMock) NoResultMethod(ctx context.Context) error {
+	s.logger.Log("msg", "mockService.NoResultMethod", "mock", true)
+	switch name := kitmock.Error(ctx); name {
+	case "":
+	case "bad_request":
+		return mockservice.MakeBadRequest(kitmock.NewError("bad_request"))
+	default:
+		return kitmock.UnknownError(name)
+	}
+	return nil
+}
+`
@@ -0,0 +1,162 @@
+package testdata
+
+var GoaEndpointCode = `package foo
+
+import (
+	"context"
+
+	goa "goa.design/goa"
+)
+
+// Endpoints wraps the foo service methods.
+type Endpoints struct {
+	Add goa.Endpoint
+}
+
+// Use applies the given middleware to all the endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Add = m(e.Add)
+}
+
+// NewAddEndpoint returns an endpoint that always fails.
+func NewAddEndpoint() goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, goa.PermanentError("failure", "always fails")
+	}
+}
+`
+
+var GoaEndpointGoakitifiedCode = `package foo
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	goa "goa.design/goa"
+)
+
+// Endpoints wraps the foo service methods.
+type Endpoints struct {
+	Add endpoint.Endpoint
+}
+
+// Use applies the given middleware to all the endpoints.
+func (e *Endpoints) Use(m func(endpoint.Endpoint) endpoint.Endpoint) {
+	e.Add = m(e.Add)
+}
+
+// NewAddEndpoint returns an endpoint that always fails.
+func NewAddEndpoint() endpoint.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, goa.PermanentError("failure", "always fails")
+	}
+}
+`
+
+var AliasedGoaEndpointCode = `package foo
+
+import (
+	goalib "goa.design/goa"
+)
+
+// Middleware is an endpoint middleware.
+type Middleware func(goalib.Endpoint) goalib.Endpoint
+`
+
+var AliasedGoaEndpointGoakitifiedCode = `package foo
+
+import (
+	"github.com/go-kit/kit/endpoint"
+)
+
+// Middleware is an endpoint middleware.
+type Middleware func(endpoint.Endpoint) endpoint.Endpoint
+`
+
+var CommentGoaEndpointCode = `package foo
+
+import (
+	goa "goa.design/goa"
+)
+
+// Kind is the name of the type of the endpoints, goa.Endpoint.
+const Kind = "goa.Endpoint"
+
+// Err is the error returned by the endpoints.
+var Err = goa.PermanentError("failure", "always fails")
+`
+
+var ShadowedGoaEndpointCode = `package foo
+
+import (
+	goa "goa.design/goa"
+)
+
+// Service lists the service endpoints.
+type Service struct {
+	Endpoint func() error
+}
+
+// Run runs the service endpoint.
+func Run(goa *Service) error {
+	return goa.Endpoint()
+}
+
+// Err is the error returned by the endpoints.
+var Err = goa.PermanentError("failure", "always fails")
+`
+
+var KitImportedGoaEndpointCode = `package foo
+
+import (
+	kitep "github.com/go-kit/kit/endpoint"
+	goa "goa.design/goa"
+)
+
+// Chain chains the given middlewares.
+func Chain(outer kitep.Middleware, others ...kitep.Middleware) func(goa.Endpoint) goa.Endpoint {
+	return kitep.Chain(outer, others...)
+}
+`
+
+var KitImportedGoaEndpointGoakitifiedCode = `package foo
+
+import (
+	kitep "github.com/go-kit/kit/endpoint"
+)
+
+// Chain chains the given middlewares.
+func Chain(outer kitep.Middleware, others ...kitep.Middleware) func(kitep.Endpoint) kitep.Endpoint {
+	return kitep.Chain(outer, others...)
+}
+`
+
+var EndpointDeclaredGoaEndpointCode = `package foo
+
+import (
+	goa "goa.design/goa"
+)
+
+// endpoint is the endpoint served by the server.
+var endpoint goa.Endpoint
+`
+
+var EndpointDeclaredGoaEndpointGoakitifiedCode = `package foo
+
+import (
+	kitendpoint "github.com/go-kit/kit/endpoint"
+)
+
+// endpoint is the endpoint served by the server.
+var endpoint kitendpoint.Endpoint
+`
+
+var NoGoaImportCode = `package foo
+
+import (
+	"github.com/go-kit/kit/endpoint"
+)
+
+// Nop is an endpoint that does nothing.
+var Nop endpoint.Endpoint = endpoint.Nop
+`
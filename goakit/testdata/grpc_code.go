@@ -0,0 +1,257 @@
+package testdata
+
+var NoopGRPCResponseEncoderCode = `// EncodeNoopResponse is a go-kit EncodeResponseFunc suitable for encoding noop
+// responses. It wraps the goa generated encoder and sets the response header
+// and trailer metadata.
+func EncodeNoopResponse(ctx context.Context, v interface{}) (interface{}, error) {
+	var hdr, trlr metadata.MD
+	resp, err := server.EncodeNoopResponse(ctx, v, &hdr, &trlr)
+	if err != nil {
+		return nil, err
+	}
+	if len(hdr) > 0 {
+		if err := grpc.SetHeader(ctx, hdr); err != nil {
+			return nil, err
+		}
+	}
+	if len(trlr) > 0 {
+		if err := grpc.SetTrailer(ctx, trlr); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+`
+
+var SimpleGRPCServerCode = `// Server implements the simplepb.SimpleServer interface using go-kit gRPC
+// handlers.
+type Server struct {
+	NoopH kitgrpc.Handler
+}
+
+// NewServer returns a go-kit gRPC server for the simple service endpoints.
+func NewServer(e *simple.Endpoints, opts ...kitgrpc.ServerOption) *Server {
+	return &Server{
+		NoopH: kitgrpc.NewServer(
+			e.Noop,
+			func(context.Context, interface{}) (interface{}, error) { return nil, nil },
+			EncodeNoopResponse,
+			opts...,
+		),
+	}
+}
+`
+
+var NoopGRPCServerMethodCode = `// Noop implements the "Noop" method in simplepb.SimpleServer interface.
+func (s *Server) Noop(ctx context.Context, message *simplepb.NoopRequest) (*simplepb.NoopResponse, error) {
+	_, resp, err := s.NoopH.ServeGRPC(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*simplepb.NoopResponse), nil
+}
+`
+
+var SimpleGRPCClientNewCode = `// New returns the simple service endpoints implemented with go-kit gRPC
+// clients that make requests using the given connection.
+func New(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) *simple.Endpoints {
+	return &simple.Endpoints{
+		Noop: NewNoopClient(cc, opts...),
+	}
+}
+`
+
+var NoopGRPCClientCode = `// NewNoopClient returns an endpoint that makes gRPC requests to the simple
+// service noop method using a go-kit gRPC client.
+func NewNoopClient(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) endpoint.Endpoint {
+	return newClient(
+		cc,
+		"simple.Simple",
+		"Noop",
+		func(context.Context, interface{}, *metadata.MD) (interface{}, error) {
+			return &simplepb.NoopRequest{}, nil
+		},
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil },
+		simplepb.NoopResponse{},
+		opts...,
+	)
+}
+`
+
+var AddGRPCRequestDecoderCode = `// DecodeAddRequest is a go-kit DecodeRequestFunc suitable for decoding add
+// requests. It wraps the goa generated decoder and provides it with the
+// incoming request metadata.
+func DecodeAddRequest(ctx context.Context, v interface{}) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return server.DecodeAddRequest(ctx, v, md)
+}
+`
+
+var AddGRPCResponseEncoderCode = `// EncodeAddResponse is a go-kit EncodeResponseFunc suitable for encoding add
+// responses. It wraps the goa generated encoder and sets the response header
+// and trailer metadata.
+func EncodeAddResponse(ctx context.Context, v interface{}) (interface{}, error) {
+	var hdr, trlr metadata.MD
+	resp, err := server.EncodeAddResponse(ctx, v, &hdr, &trlr)
+	if err != nil {
+		return nil, err
+	}
+	if len(hdr) > 0 {
+		if err := grpc.SetHeader(ctx, hdr); err != nil {
+			return nil, err
+		}
+	}
+	if len(trlr) > 0 {
+		if err := grpc.SetTrailer(ctx, trlr); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+`
+
+var CalcGRPCServerCode = `// Server implements the calcpb.CalcServer interface using go-kit gRPC handlers.
+type Server struct {
+	AddH kitgrpc.Handler
+}
+
+// NewServer returns a go-kit gRPC server for the calc service endpoints.
+func NewServer(e *calc.Endpoints, opts ...kitgrpc.ServerOption) *Server {
+	return &Server{
+		AddH: kitgrpc.NewServer(
+			e.Add,
+			DecodeAddRequest,
+			EncodeAddResponse,
+			opts...,
+		),
+	}
+}
+`
+
+var AddGRPCServerMethodCode = `// Add implements the "Add" method in calcpb.CalcServer interface.
+func (s *Server) Add(ctx context.Context, message *calcpb.AddRequest) (*calcpb.AddResponse, error) {
+	_, resp, err := s.AddH.ServeGRPC(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*calcpb.AddResponse), nil
+}
+`
+
+var AddGRPCResponseDecoderCode = `// DecodeAddResponse is a go-kit DecodeResponseFunc suitable for decoding add
+// responses. It wraps the goa generated decoder and provides it with the
+// response metadata.
+func DecodeAddResponse(ctx context.Context, v interface{}) (interface{}, error) {
+	hdr, trlr := responseMetadata(ctx)
+	return client.DecodeAddResponse(ctx, v, hdr, trlr)
+}
+`
+
+var CalcGRPCClientNewCode = `// New returns the calc service endpoints implemented with go-kit gRPC clients
+// that make requests using the given connection.
+func New(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) *calc.Endpoints {
+	return &calc.Endpoints{
+		Add: NewAddClient(cc, opts...),
+	}
+}
+`
+
+var AddGRPCClientCode = `// NewAddClient returns an endpoint that makes gRPC requests to the calc
+// service add method using a go-kit gRPC client.
+func NewAddClient(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) endpoint.Endpoint {
+	return newClient(
+		cc,
+		"calc.Calc",
+		"Add",
+		client.EncodeAddRequest,
+		DecodeAddResponse,
+		calcpb.AddResponse{},
+		opts...,
+	)
+}
+`
+
+var UnaryGRPCRequestDecoderCode = `// DecodeUnaryRequest is a go-kit DecodeRequestFunc suitable for decoding unary
+// requests. It wraps the goa generated decoder and provides it with the
+// incoming request metadata.
+func DecodeUnaryRequest(ctx context.Context, v interface{}) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return server.DecodeUnaryRequest(ctx, v, md)
+}
+`
+
+var UnaryGRPCResponseEncoderCode = `// EncodeUnaryResponse is a go-kit EncodeResponseFunc suitable for encoding
+// unary responses. It wraps the goa generated encoder and sets the response
+// header and trailer metadata.
+func EncodeUnaryResponse(ctx context.Context, v interface{}) (interface{}, error) {
+	var hdr, trlr metadata.MD
+	resp, err := server.EncodeUnaryResponse(ctx, v, &hdr, &trlr)
+	if err != nil {
+		return nil, err
+	}
+	if len(hdr) > 0 {
+		if err := grpc.SetHeader(ctx, hdr); err != nil {
+			return nil, err
+		}
+	}
+	if len(trlr) > 0 {
+		if err := grpc.SetTrailer(ctx, trlr); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+`
+
+var StreamingGRPCServerCode = `// Server implements the streamingpb.StreamingServer interface using go-kit
+// gRPC handlers.
+type Server struct {
+	UnaryH kitgrpc.Handler
+}
+
+// NewServer returns a go-kit gRPC server for the streaming service endpoints.
+func NewServer(e *streaming.Endpoints, opts ...kitgrpc.ServerOption) *Server {
+	return &Server{
+		UnaryH: kitgrpc.NewServer(
+			e.Unary,
+			DecodeUnaryRequest,
+			EncodeUnaryResponse,
+			opts...,
+		),
+	}
+}
+`
+
+var UnaryGRPCServerMethodCode = `// Unary implements the "Unary" method in streamingpb.StreamingServer interface.
+func (s *Server) Unary(ctx context.Context, message *streamingpb.UnaryRequest) (*streamingpb.UnaryResponse, error) {
+	_, resp, err := s.UnaryH.ServeGRPC(ctx, message)
+	if err != nil {
+		return nil, goagrpc.EncodeError(err)
+	}
+	return resp.(*streamingpb.UnaryResponse), nil
+}
+`
+
+var StreamingGRPCClientNewCode = `// New returns the streaming service endpoints implemented with go-kit gRPC
+// clients that make requests using the given connection.
+func New(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) *streaming.Endpoints {
+	return &streaming.Endpoints{
+		Unary: NewUnaryClient(cc, opts...),
+	}
+}
+`
+
+var UnaryGRPCClientCode = `// NewUnaryClient returns an endpoint that makes gRPC requests to the streaming
+// service unary method using a go-kit gRPC client.
+func NewUnaryClient(cc *grpc.ClientConn, opts ...kitgrpc.ClientOption) endpoint.Endpoint {
+	return newClient(
+		cc,
+		"streaming.Streaming",
+		"Unary",
+		client.EncodeUnaryRequest,
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil },
+		streamingpb.UnaryResponse{},
+		opts...,
+	)
+}
+`
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	. "goa.design/goa/grpc/design"
+	. "goa.design/goa/grpc/dsl"
+)
+
+var GRPCSimpleDSL = func() {
+	Service("simple", func() {
+		Method("noop", func() {
+			GRPC(func() {})
+		})
+	})
+}
+
+var GRPCCalcDSL = func() {
+	Service("calc", func() {
+		Method("add", func() {
+			Payload(func() {
+				Field(1, "a", Int)
+				Field(2, "b", Int)
+			})
+			Result(Int)
+			GRPC(func() {})
+		})
+	})
+}
+
+var GRPCStreamingDSL = func() {
+	Service("streaming", func() {
+		Method("unary", func() {
+			Payload(String)
+			GRPC(func() {})
+		})
+		Method("stream", func() {
+			StreamingResult(String)
+			GRPC(func() {})
+		})
+	})
+}
@@ -0,0 +1,55 @@
+package testdata
+
+var RPCServiceJSONRPCCodecMapCode = `// NewEndpointCodecMap returns the go-kit JSON-RPC codecs of the RPCService
+// service methods indexed by method name. The params are decoded with the goa
+// generated HTTP request decoders so that they are validated like the HTTP
+// requests and the results are encoded with the goa generated HTTP response
+// encoders.
+func NewEndpointCodecMap(e *rpcservice.Endpoints) jsonrpc.EndpointCodecMap {
+	return jsonrpc.EndpointCodecMap{
+		"ParamsMethod": jsonrpc.EndpointCodec{
+			Endpoint: e.ParamsMethod,
+			Decode:   kitjsonrpc.DecodeParams(server.DecodeParamsMethodRequest, "/items/{id}", "tags"),
+			Encode:   kitjsonrpc.EncodeResult(server.EncodeParamsMethodResponse),
+		},
+		"NoPayloadMethod": jsonrpc.EndpointCodec{
+			Endpoint: e.NoPayloadMethod,
+			Decode:   kitjsonrpc.DecodeNoParams,
+			Encode:   kitjsonrpc.EncodeResult(server.EncodeNoPayloadMethodResponse),
+		},
+	}
+}
+`
+
+var RPCServiceJSONRPCClientNewCode = `// New returns the RPCService service endpoints implemented with go-kit
+// JSON-RPC clients that make requests to the given host using the given scheme.
+func New(scheme, host string, opts ...jsonrpc.ClientOption) *rpcservice.Endpoints {
+	u := &url.URL{Scheme: scheme, Host: host, Path: Path}
+	return &rpcservice.Endpoints{
+		ParamsMethod:    NewParamsMethodClient(u, opts...).Endpoint(),
+		NoPayloadMethod: NewNoPayloadMethodClient(u, opts...).Endpoint(),
+	}
+}
+`
+
+var ParamsMethodJSONRPCClientCode = `// NewParamsMethodClient returns a go-kit JSON-RPC client that calls the
+// RPCService service ParamsMethod method. The params and result are encoded
+// and decoded with the goa generated HTTP client functions.
+func NewParamsMethodClient(u *url.URL, opts ...jsonrpc.ClientOption) *jsonrpc.Client {
+	c := client.NewClient(u.Scheme, u.Host, nil, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	return jsonrpc.NewClient(u, "ParamsMethod", append([]jsonrpc.ClientOption{
+		jsonrpc.ClientRequestEncoder(kitjsonrpc.EncodeParams(c.BuildParamsMethodRequest, client.EncodeParamsMethodRequest(goahttp.RequestEncoder), "/items/{id}")),
+		jsonrpc.ClientResponseDecoder(kitjsonrpc.DecodeResult(client.DecodeParamsMethodResponse, http.StatusCreated)),
+	}, opts...)...)
+}
+`
+
+var NoPayloadMethodJSONRPCClientCode = `// NewNoPayloadMethodClient returns a go-kit JSON-RPC client that calls the
+// RPCService service NoPayloadMethod method.
+func NewNoPayloadMethodClient(u *url.URL, opts ...jsonrpc.ClientOption) *jsonrpc.Client {
+	return jsonrpc.NewClient(u, "NoPayloadMethod", append([]jsonrpc.ClientOption{
+		jsonrpc.ClientRequestEncoder(kitjsonrpc.EncodeNoParams),
+		jsonrpc.ClientResponseDecoder(kitjsonrpc.DecodeNoResult),
+	}, opts...)...)
+}
+`
@@ -0,0 +1,45 @@
+package testdata
+
+var MetricsCode = `// Namespace is the Prometheus namespace of the calc-api API metrics.
+const Namespace = "calc_api"
+
+// Metrics holds the go-kit Prometheus metrics that instrument the calc-api API
+// endpoints. The metrics are labeled with "service", "method" and "error", the
+// name of the error returned by the endpoint as defined in the design,
+// "unknown" if the error is not a designed error or the empty string if the
+// request succeeded.
+type Metrics struct {
+	// Requests counts the requests.
+	Requests metrics.Counter
+	// Duration records the duration of the requests in seconds.
+	Duration metrics.Histogram
+}
+
+// New creates the metrics and registers them with the default Prometheus
+// registry. New panics if called more than once as the metrics are already
+// registered.
+func New() *Metrics {
+	labels := []string{"service", "method", "error"}
+	return &Metrics{
+		Requests: kitprometheus.NewCounterFrom(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests received.",
+		}, labels),
+		Duration: kitprometheus.NewHistogramFrom(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests in seconds.",
+		}, labels),
+	}
+}
+
+// Middleware returns the kitendpoint.Instrumenting middlewares that record the
+// metrics of the given service methods. The function is suitable for the
+// Apply method of the service Endpoints struct:
+//
+//	endpoints.Apply(m.Middleware(calcsvc.ServiceName))
+func (m *Metrics) Middleware(service string) func(method string) endpoint.Middleware {
+	return kitendpoint.Instrumenting(m.Duration, m.Requests, service)
+}
+`
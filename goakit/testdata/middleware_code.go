@@ -0,0 +1,100 @@
+package testdata
+
+var SimpleServiceMiddlewareCode = `// Middleware is a SimpleService service middleware.
+type Middleware func(Service) Service
+
+// LoggingMiddleware returns a SimpleService service middleware that logs the
+// method names, the duration and the error (if any) of the method calls.
+func LoggingMiddleware(logger log.Logger) Middleware {
+	return func(next Service) Service {
+		return &loggingMiddleware{logger: logger, next: next}
+	}
+}
+
+// InstrumentingMiddleware returns a SimpleService service middleware that
+// records the duration of the method calls in seconds with the given histogram
+// and counts them with the given counter. Both metrics are labeled with
+// "service", "method" and "success" ("true" if the method returned no error,
+// "false" otherwise).
+func InstrumentingMiddleware(duration metrics.Histogram, requests metrics.Counter) Middleware {
+	return func(next Service) Service {
+		return &instrumentingMiddleware{duration: duration, requests: requests, next: next}
+	}
+}
+
+// loggingMiddleware is the service middleware built by LoggingMiddleware.
+type loggingMiddleware struct {
+	logger log.Logger
+	next   Service
+}
+
+// instrumentingMiddleware is the service middleware built by
+// InstrumentingMiddleware.
+type instrumentingMiddleware struct {
+	duration metrics.Histogram
+	requests metrics.Counter
+	next     Service
+}
+`
+
+var SimpleServiceLoggingMiddlewareCode = `// SimpleMethod logs the SimpleMethod method calls.
+func (mw *loggingMiddleware) SimpleMethod(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("service", ServiceName, "method", "SimpleMethod", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.SimpleMethod(ctx)
+}
+`
+
+var SimpleServiceInstrumentingMiddlewareCode = `// SimpleMethod records the duration and count of the SimpleMethod method calls.
+func (mw *instrumentingMiddleware) SimpleMethod(ctx context.Context) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"service", ServiceName, "method", "SimpleMethod", "success", strconv.FormatBool(err == nil)}
+		mw.duration.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.requests.With(lvs...).Add(1)
+	}(time.Now())
+	return mw.next.SimpleMethod(ctx)
+}
+`
+
+var WithPayloadLoggingMiddlewareCode = `// WithPayloadMethod logs the WithPayloadMethod method calls.
+func (mw *loggingMiddleware) WithPayloadMethod(ctx context.Context, p *WithPayloadMethodPayload) (err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("service", ServiceName, "method", "WithPayloadMethod", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.WithPayloadMethod(ctx, p)
+}
+`
+
+var WithPayloadInstrumentingMiddlewareCode = `// WithPayloadMethod records the duration and count of the WithPayloadMethod
+// method calls.
+func (mw *instrumentingMiddleware) WithPayloadMethod(ctx context.Context, p *WithPayloadMethodPayload) (err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"service", ServiceName, "method", "WithPayloadMethod", "success", strconv.FormatBool(err == nil)}
+		mw.duration.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.requests.With(lvs...).Add(1)
+	}(time.Now())
+	return mw.next.WithPayloadMethod(ctx, p)
+}
+`
+
+var WithResultLoggingMiddlewareCode = `// WithResultMethod logs the WithResultMethod method calls.
+func (mw *loggingMiddleware) WithResultMethod(ctx context.Context, p string) (res string, err error) {
+	defer func(begin time.Time) {
+		mw.logger.Log("service", ServiceName, "method", "WithResultMethod", "took", time.Since(begin), "err", err)
+	}(time.Now())
+	return mw.next.WithResultMethod(ctx, p)
+}
+`
+
+var WithResultInstrumentingMiddlewareCode = `// WithResultMethod records the duration and count of the WithResultMethod
+// method calls.
+func (mw *instrumentingMiddleware) WithResultMethod(ctx context.Context, p string) (res string, err error) {
+	defer func(begin time.Time) {
+		lvs := []string{"service", ServiceName, "method", "WithResultMethod", "success", strconv.FormatBool(err == nil)}
+		mw.duration.With(lvs...).Observe(time.Since(begin).Seconds())
+		mw.requests.With(lvs...).Add(1)
+	}(time.Now())
+	return mw.next.WithResultMethod(ctx, p)
+}
+`
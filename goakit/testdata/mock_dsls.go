@@ -0,0 +1,64 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/plugins/goakit/dsl"
+)
+
+var MockDSL = func() {
+	var Item = ResultType("application/vnd.item", func() {
+		TypeName("Item")
+		Attributes(func() {
+			Attribute("href", String, func() {
+				Pattern("^/items/[0-9]+$")
+				Example("/items/1")
+			})
+			Attribute("count", Int, func() {
+				Minimum(0)
+				Example(3)
+			})
+			Attribute("tags", ArrayOf(String), func() {
+				Example([]string{"a", "b"})
+			})
+			Attribute("note", String, func() {
+				Example("note")
+			})
+			Attribute("owner", func() {
+				Attribute("name", String, func() {
+					Example("joe")
+				})
+			})
+			Required("href", "count", "tags")
+		})
+	})
+	Service("MockService", func() {
+		Mock()
+		Method("ResultMethod", func() {
+			Description("ResultMethod returns an item.")
+			Payload(func() {
+				Attribute("id", Int)
+			})
+			Result(Item)
+			Error("bad_request")
+			HTTP(func() {
+				GET("/items/{id}")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+		Method("NoResultMethod", func() {
+			Description("NoResultMethod has no result.")
+			Error("bad_request")
+			HTTP(func() {
+				POST("/")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+	Service("UnmockedService", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
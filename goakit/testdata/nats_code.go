@@ -0,0 +1,103 @@
+package testdata
+
+var NATSServiceNATSSubjectsCode = `// Queue is the name of the queue group joined by the NATSService service
+// subscribers so that each request is served once.
+const Queue = "NATSService"
+
+const (
+	// ParamsMethodSubject is the subject of the NATS requests made to the
+	// NATSService service ParamsMethod method.
+	ParamsMethodSubject = "items.update"
+	// NoPayloadMethodSubject is the subject of the NATS requests made to the
+	// NATSService service NoPayloadMethod method.
+	NoPayloadMethodSubject = "NATSService.NoPayloadMethod"
+)
+`
+
+var NATSServiceNATSSubscribeCode = `// Subscribe subscribes the NATSService service NATS servers to the method
+// subjects using the Queue queue group. The servers publish the replies using
+// the given connection.
+func Subscribe(nc *nats.Conn, e *natsservice.Endpoints, opts ...natstransport.SubscriberOption) ([]*nats.Subscription, error) {
+	servers := map[string]*natstransport.Subscriber{
+		ParamsMethodSubject:    NewParamsMethodServer(e.ParamsMethod, opts...),
+		NoPayloadMethodSubject: NewNoPayloadMethodServer(e.NoPayloadMethod, opts...),
+	}
+	subs := make([]*nats.Subscription, 0, len(servers))
+	for subject, s := range servers {
+		sub, err := nc.QueueSubscribe(subject, Queue, s.ServeMsg(nc))
+		if err != nil {
+			for _, sub := range subs {
+				sub.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, nil
+}
+`
+
+var ParamsMethodNATSServerCode = `// NewParamsMethodServer returns a go-kit NATS subscriber that serves the
+// NATSService service ParamsMethod method. The request data is decoded with
+// the goa generated HTTP request decoder so that it is validated like the HTTP
+// requests.
+func NewParamsMethodServer(e endpoint.Endpoint, opts ...natstransport.SubscriberOption) *natstransport.Subscriber {
+	return natstransport.NewSubscriber(
+		e,
+		kitnats.DecodeRequest(server.DecodeParamsMethodRequest, "/items/{id}", "tags"),
+		kitnats.EncodeResponse(server.EncodeParamsMethodResponse),
+		append([]natstransport.SubscriberOption{natstransport.SubscriberErrorEncoder(kitnats.EncodeError)}, opts...)...,
+	)
+}
+`
+
+var NoPayloadMethodNATSServerCode = `// NewNoPayloadMethodServer returns a go-kit NATS subscriber that serves the
+// NATSService service NoPayloadMethod method.
+func NewNoPayloadMethodServer(e endpoint.Endpoint, opts ...natstransport.SubscriberOption) *natstransport.Subscriber {
+	return natstransport.NewSubscriber(
+		e,
+		kitnats.DecodeNoRequest,
+		kitnats.EncodeResponse(server.EncodeNoPayloadMethodResponse),
+		append([]natstransport.SubscriberOption{natstransport.SubscriberErrorEncoder(kitnats.EncodeError)}, opts...)...,
+	)
+}
+`
+
+var NATSServiceNATSClientNewCode = `// New returns the NATSService service endpoints implemented with go-kit NATS
+// publishers that make requests using the given connection.
+func New(nc *nats.Conn, opts ...natstransport.PublisherOption) *natsservice.Endpoints {
+	return &natsservice.Endpoints{
+		ParamsMethod:    NewParamsMethodClient(nc, opts...).Endpoint(),
+		NoPayloadMethod: NewNoPayloadMethodClient(nc, opts...).Endpoint(),
+	}
+}
+`
+
+var ParamsMethodNATSClientCode = `// NewParamsMethodClient returns a go-kit NATS publisher that makes requests to
+// the NATSService service ParamsMethod method.
+func NewParamsMethodClient(nc *nats.Conn, opts ...natstransport.PublisherOption) *natstransport.Publisher {
+	// The HTTP client is only used to build the request params, it makes no
+	// HTTP request.
+	c := client.NewClient("", "", nil, goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+	return natstransport.NewPublisher(
+		nc,
+		ParamsMethodSubject,
+		kitnats.EncodeRequest(c.BuildParamsMethodRequest, client.EncodeParamsMethodRequest(goahttp.RequestEncoder), "/items/{id}"),
+		kitnats.DecodeResponse(client.DecodeParamsMethodResponse, http.StatusCreated),
+		opts...,
+	)
+}
+`
+
+var NoPayloadMethodNATSClientCode = `// NewNoPayloadMethodClient returns a go-kit NATS publisher that makes requests
+// to the NATSService service NoPayloadMethod method.
+func NewNoPayloadMethodClient(nc *nats.Conn, opts ...natstransport.PublisherOption) *natstransport.Publisher {
+	return natstransport.NewPublisher(
+		nc,
+		NoPayloadMethodSubject,
+		kitnats.EncodeNoRequest,
+		kitnats.DecodeNoResponse,
+		opts...,
+	)
+}
+`
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/plugins/goakit/dsl"
+)
+
+var NATSDSL = func() {
+	Service("NATSService", func() {
+		NATS()
+		Method("ParamsMethod", func() {
+			Payload(func() {
+				Attribute("id", Int)
+				Attribute("tags", ArrayOf(String))
+				Attribute("name", String)
+			})
+			Result(String)
+			NATS(func() {
+				Subject("items.update")
+			})
+			HTTP(func() {
+				PUT("/items/{id}")
+				Param("tags")
+				Response(StatusCreated)
+			})
+		})
+		Method("NoPayloadMethod", func() {
+			HTTP(func() {
+				GET("/status")
+			})
+		})
+	})
+}
@@ -0,0 +1,288 @@
+package testdata
+
+var SimpleServiceLoadBalancedEndpointsCode = `// LoadBalancerOptions configures the endpoints returned by
+// NewLoadBalancedEndpoints. The zero value of each field selects the default.
+type LoadBalancerOptions struct {
+	// Scheme is the scheme used to make requests to the instances that do
+	// not specify one, "http" by default.
+	Scheme string
+	// Encoder builds the request body encoders, goahttp.RequestEncoder by
+	// default.
+	Encoder func(*http.Request) goahttp.Encoder
+	// Decoder builds the response body decoders, goahttp.ResponseDecoder by
+	// default.
+	Decoder func(*http.Response) goahttp.Decoder
+	// MaxAttempts is the maximum number of attempts made for each request
+	// to a method marked as idempotent in the design across the instances,
+	// 3 by default. The requests to the methods that define retries in the
+	// design are attempted the number of times defined in the design, the
+	// requests to the other methods are attempted once.
+	MaxAttempts int
+	// Timeout is the maximum duration of each request including all the
+	// attempts, 10 seconds by default. The timeout defined in the design
+	// takes precedence.
+	Timeout time.Duration
+	// ClientOptions are the options used to create the go-kit HTTP clients.
+	ClientOptions []kithttp.ClientOption
+}
+
+// NewLoadBalancedEndpoints returns the SimpleService service endpoints
+// implemented with go-kit HTTP clients that make requests to the instances
+// published by the given instancer. The requests are balanced across the
+// instances using a round-robin strategy and the failed requests to the
+// idempotent methods are retried on the next instance. The circuit breakers
+// defined in the design wrap the endpoint of each instance. The instances are
+// either "host:port" strings or URLs. opts may be nil.
+func NewLoadBalancedEndpoints(instancer sd.Instancer, logger log.Logger, opts *LoadBalancerOptions) *simpleservice.Endpoints {
+	o := LoadBalancerOptions{
+		Scheme:      "http",
+		Encoder:     goahttp.RequestEncoder,
+		Decoder:     goahttp.ResponseDecoder,
+		MaxAttempts: 3,
+		Timeout:     10 * time.Second,
+	}
+	if opts != nil {
+		if opts.Scheme != "" {
+			o.Scheme = opts.Scheme
+		}
+		if opts.Encoder != nil {
+			o.Encoder = opts.Encoder
+		}
+		if opts.Decoder != nil {
+			o.Decoder = opts.Decoder
+		}
+		if opts.MaxAttempts > 0 {
+			o.MaxAttempts = opts.MaxAttempts
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		o.ClientOptions = opts.ClientOptions
+	}
+	balance := func(f sd.Factory, attempts int, timeout time.Duration, mw endpoint.Middleware) endpoint.Endpoint {
+		if mw != nil {
+			factory := f
+			f = func(instance string) (endpoint.Endpoint, io.Closer, error) {
+				e, c, err := factory(instance)
+				if err != nil {
+					return nil, nil, err
+				}
+				return mw(e), c, nil
+			}
+		}
+		endpointer := sd.NewEndpointer(instancer, f, logger)
+		return lb.Retry(attempts, timeout, lb.NewRoundRobin(endpointer))
+	}
+	return &simpleservice.Endpoints{
+		SimpleMethod: balance(NewSimpleMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 1, o.Timeout, nil),
+	}
+}
+`
+
+var SimpleMethodSDFactoryCode = `// NewSimpleMethodFactory returns a go-kit sd.Factory that builds the
+// SimpleService service SimpleMethod endpoint for a given instance. The
+// instance is either a "host:port" string in which case the given scheme is
+// used or a URL.
+func NewSimpleMethodFactory(scheme string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) sd.Factory {
+	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		s, host, err := splitInstance(scheme, instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewSimpleMethodClient(s, host, enc, dec, opts...).Endpoint(), nil, nil
+	}
+}
+`
+
+var MultiEndpointServiceLoadBalancedEndpointsCode = `// LoadBalancerOptions configures the endpoints returned by
+// NewLoadBalancedEndpoints. The zero value of each field selects the default.
+type LoadBalancerOptions struct {
+	// Scheme is the scheme used to make requests to the instances that do
+	// not specify one, "http" by default.
+	Scheme string
+	// Encoder builds the request body encoders, goahttp.RequestEncoder by
+	// default.
+	Encoder func(*http.Request) goahttp.Encoder
+	// Decoder builds the response body decoders, goahttp.ResponseDecoder by
+	// default.
+	Decoder func(*http.Response) goahttp.Decoder
+	// MaxAttempts is the maximum number of attempts made for each request
+	// to a method marked as idempotent in the design across the instances,
+	// 3 by default. The requests to the methods that define retries in the
+	// design are attempted the number of times defined in the design, the
+	// requests to the other methods are attempted once.
+	MaxAttempts int
+	// Timeout is the maximum duration of each request including all the
+	// attempts, 10 seconds by default. The timeout defined in the design
+	// takes precedence.
+	Timeout time.Duration
+	// ClientOptions are the options used to create the go-kit HTTP clients.
+	ClientOptions []kithttp.ClientOption
+}
+
+// NewLoadBalancedEndpoints returns the MultiEndpointService service endpoints
+// implemented with go-kit HTTP clients that make requests to the instances
+// published by the given instancer. The requests are balanced across the
+// instances using a round-robin strategy and the failed requests to the
+// idempotent methods are retried on the next instance. The circuit breakers
+// defined in the design wrap the endpoint of each instance. The instances are
+// either "host:port" strings or URLs. opts may be nil.
+func NewLoadBalancedEndpoints(instancer sd.Instancer, logger log.Logger, opts *LoadBalancerOptions) *multiendpointservice.Endpoints {
+	o := LoadBalancerOptions{
+		Scheme:      "http",
+		Encoder:     goahttp.RequestEncoder,
+		Decoder:     goahttp.ResponseDecoder,
+		MaxAttempts: 3,
+		Timeout:     10 * time.Second,
+	}
+	if opts != nil {
+		if opts.Scheme != "" {
+			o.Scheme = opts.Scheme
+		}
+		if opts.Encoder != nil {
+			o.Encoder = opts.Encoder
+		}
+		if opts.Decoder != nil {
+			o.Decoder = opts.Decoder
+		}
+		if opts.MaxAttempts > 0 {
+			o.MaxAttempts = opts.MaxAttempts
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		o.ClientOptions = opts.ClientOptions
+	}
+	balance := func(f sd.Factory, attempts int, timeout time.Duration, mw endpoint.Middleware) endpoint.Endpoint {
+		if mw != nil {
+			factory := f
+			f = func(instance string) (endpoint.Endpoint, io.Closer, error) {
+				e, c, err := factory(instance)
+				if err != nil {
+					return nil, nil, err
+				}
+				return mw(e), c, nil
+			}
+		}
+		endpointer := sd.NewEndpointer(instancer, f, logger)
+		return lb.Retry(attempts, timeout, lb.NewRoundRobin(endpointer))
+	}
+	return &multiendpointservice.Endpoints{
+		Endpoint1: balance(NewEndpoint1Factory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 1, o.Timeout, nil),
+		Endpoint2: balance(NewEndpoint2Factory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 1, o.Timeout, nil),
+	}
+}
+`
+
+var Endpoint1SDFactoryCode = `// NewEndpoint1Factory returns a go-kit sd.Factory that builds the
+// MultiEndpointService service Endpoint1 endpoint for a given instance. The
+// instance is either a "host:port" string in which case the given scheme is
+// used or a URL.
+func NewEndpoint1Factory(scheme string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) sd.Factory {
+	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		s, host, err := splitInstance(scheme, instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewEndpoint1Client(s, host, enc, dec, opts...).Endpoint(), nil, nil
+	}
+}
+`
+
+var Endpoint2SDFactoryCode = `// NewEndpoint2Factory returns a go-kit sd.Factory that builds the
+// MultiEndpointService service Endpoint2 endpoint for a given instance. The
+// instance is either a "host:port" string in which case the given scheme is
+// used or a URL.
+func NewEndpoint2Factory(scheme string, enc func(*http.Request) goahttp.Encoder, dec func(*http.Response) goahttp.Decoder, opts ...kithttp.ClientOption) sd.Factory {
+	return func(instance string) (endpoint.Endpoint, io.Closer, error) {
+		s, host, err := splitInstance(scheme, instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewEndpoint2Client(s, host, enc, dec, opts...).Endpoint(), nil, nil
+	}
+}
+`
+
+var ClientServiceLoadBalancedEndpointsCode = `// LoadBalancerOptions configures the endpoints returned by
+// NewLoadBalancedEndpoints. The zero value of each field selects the default.
+type LoadBalancerOptions struct {
+	// Scheme is the scheme used to make requests to the instances that do
+	// not specify one, "http" by default.
+	Scheme string
+	// Encoder builds the request body encoders, goahttp.RequestEncoder by
+	// default.
+	Encoder func(*http.Request) goahttp.Encoder
+	// Decoder builds the response body decoders, goahttp.ResponseDecoder by
+	// default.
+	Decoder func(*http.Response) goahttp.Decoder
+	// MaxAttempts is the maximum number of attempts made for each request
+	// to a method marked as idempotent in the design across the instances,
+	// 3 by default. The requests to the methods that define retries in the
+	// design are attempted the number of times defined in the design, the
+	// requests to the other methods are attempted once.
+	MaxAttempts int
+	// Timeout is the maximum duration of each request including all the
+	// attempts, 10 seconds by default. The timeout defined in the design
+	// takes precedence.
+	Timeout time.Duration
+	// ClientOptions are the options used to create the go-kit HTTP clients.
+	ClientOptions []kithttp.ClientOption
+}
+
+// NewLoadBalancedEndpoints returns the ClientService service endpoints
+// implemented with go-kit HTTP clients that make requests to the instances
+// published by the given instancer. The requests are balanced across the
+// instances using a round-robin strategy and the failed requests to the
+// idempotent methods are retried on the next instance. The circuit breakers
+// defined in the design wrap the endpoint of each instance. The instances are
+// either "host:port" strings or URLs. opts may be nil.
+func NewLoadBalancedEndpoints(instancer sd.Instancer, logger log.Logger, opts *LoadBalancerOptions) *clientservice.Endpoints {
+	o := LoadBalancerOptions{
+		Scheme:      "http",
+		Encoder:     goahttp.RequestEncoder,
+		Decoder:     goahttp.ResponseDecoder,
+		MaxAttempts: 3,
+		Timeout:     10 * time.Second,
+	}
+	if opts != nil {
+		if opts.Scheme != "" {
+			o.Scheme = opts.Scheme
+		}
+		if opts.Encoder != nil {
+			o.Encoder = opts.Encoder
+		}
+		if opts.Decoder != nil {
+			o.Decoder = opts.Decoder
+		}
+		if opts.MaxAttempts > 0 {
+			o.MaxAttempts = opts.MaxAttempts
+		}
+		if opts.Timeout > 0 {
+			o.Timeout = opts.Timeout
+		}
+		o.ClientOptions = opts.ClientOptions
+	}
+	balance := func(f sd.Factory, attempts int, timeout time.Duration, mw endpoint.Middleware) endpoint.Endpoint {
+		if mw != nil {
+			factory := f
+			f = func(instance string) (endpoint.Endpoint, io.Closer, error) {
+				e, c, err := factory(instance)
+				if err != nil {
+					return nil, nil, err
+				}
+				return mw(e), c, nil
+			}
+		}
+		endpointer := sd.NewEndpointer(instancer, f, logger)
+		return lb.Retry(attempts, timeout, lb.NewRoundRobin(endpointer))
+	}
+	return &clientservice.Endpoints{
+		TimeoutMethod:    balance(NewTimeoutMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 1, 2*time.Second, nil),
+		RetryMethod:      balance(NewRetryMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 3, o.Timeout, nil),
+		GobreakerMethod:  balance(NewGobreakerMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 2, 500*time.Millisecond, GobreakerMethodBreaker()),
+		HystrixMethod:    balance(NewHystrixMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), 1, o.Timeout, HystrixMethodBreaker()),
+		IdempotentMethod: balance(NewIdempotentMethodFactory(o.Scheme, o.Encoder, o.Decoder, o.ClientOptions...), o.MaxAttempts, o.Timeout, nil),
+	}
+}
+`
@@ -0,0 +1,201 @@
+package testdata
+
+var SecurityAuthorizersCode = `// Authorizers holds the go-kit middlewares and functions that authorize the
+// requests made to the secured SecuredService service endpoints. The
+// requirements that use a scheme whose authorizer is nil always fail. The
+// scopes required by the requirements are checked against the scopes returned
+// by kitauth.Scopes.
+type Authorizers struct {
+	// Basic authorizes the requests secured with the "basic" basic auth scheme,
+	// for example with basic.AuthMiddleware. The credentials are read by
+	// kithttp.PopulateRequestContext.
+	Basic endpoint.Middleware
+	// Token authorizes the requests secured with the "token" JWT scheme, for
+	// example with kitjwt.NewParser. The token is stored in the context under the
+	// kitjwt.JWTTokenContextKey key.
+	Token endpoint.Middleware
+	// HeaderToken authorizes the requests secured with the "header_token" JWT
+	// scheme, for example with kitjwt.NewParser. The token is stored in the
+	// context under the kitjwt.JWTTokenContextKey key.
+	HeaderToken endpoint.Middleware
+	// APIKey validates the API keys of the requests secured with the "api_key" API
+	// key scheme.
+	APIKey kitauth.CredentialsFunc
+	// Oauth2 validates the access tokens of the requests secured with the "oauth2"
+	// OAuth2 scheme. It may record the scopes granted to the token with
+	// kitauth.WithScopes.
+	Oauth2 kitauth.CredentialsFunc
+}
+
+// SecurityMiddleware returns a function that builds the middlewares that
+// authorize the requests made to the secured SecuredService service endpoints
+// with the authorizers a. The function is suitable for the Apply method of the
+// service Endpoints struct, it returns nil for the endpoints that are not
+// secured.
+func SecurityMiddleware(a *Authorizers) func(method string) endpoint.Middleware {
+	return func(method string) endpoint.Middleware {
+		switch method {
+		case "SecureMethod":
+			return SecureMethodSecurityMiddleware(a)
+		case "MultiMethod":
+			return MultiMethodSecurityMiddleware(a)
+		}
+		return nil
+	}
+}
+`
+
+var SecureMethodSecurityServerCode = `// SecureMethodSecurityServerBefore returns a go-kit HTTP server option that
+// reads the credentials of the requests made to the SecuredService service
+// SecureMethod endpoint. The credentials are validated by the middleware
+// returned by SecureMethodSecurityMiddleware.
+func SecureMethodSecurityServerBefore() kithttp.ServerOption {
+	return kithttp.ServerBefore(
+		kitjwt.HTTPToContext(),
+	)
+}
+
+// SecureMethodSecurityMiddleware returns a go-kit middleware that authorizes
+// the requests made to the SecuredService service SecureMethod endpoint with
+// the authorizers a. The requests are authorized if they satisfy any of the
+// security requirements of the method.
+func SecureMethodSecurityMiddleware(a *Authorizers) endpoint.Middleware {
+	return kitauth.Any(
+		kitauth.All(
+			kitauth.Scheme("token", a.Token, "api:write"),
+		),
+	)
+}
+`
+
+var MultiMethodSecurityServerCode = `// MultiMethodSecurityServerBefore returns a go-kit HTTP server option that
+// reads the credentials of the requests made to the SecuredService service
+// MultiMethod endpoint. The credentials are validated by the middleware
+// returned by MultiMethodSecurityMiddleware.
+func MultiMethodSecurityServerBefore() kithttp.ServerOption {
+	return kithttp.ServerBefore(
+		kithttp.PopulateRequestContext,
+		kitauth.APIKeyToContext("api_key", "query", "key"),
+		kitauth.JWTToContext("header", "X-Token"),
+		kitauth.OAuth2ToContext("oauth2"),
+	)
+}
+
+// MultiMethodSecurityMiddleware returns a go-kit middleware that authorizes
+// the requests made to the SecuredService service MultiMethod endpoint with
+// the authorizers a. The requests are authorized if they satisfy any of the
+// security requirements of the method.
+func MultiMethodSecurityMiddleware(a *Authorizers) endpoint.Middleware {
+	return kitauth.Any(
+		kitauth.All(
+			kitauth.Scheme("basic", a.Basic),
+			kitauth.Scheme("api_key", kitauth.APIKey("api_key", a.APIKey)),
+		),
+		kitauth.All(
+			kitauth.Scheme("header_token", a.HeaderToken),
+		),
+		kitauth.All(
+			kitauth.Scheme("oauth2", kitauth.OAuth2("oauth2", a.Oauth2), "api:write"),
+		),
+	)
+}
+`
+
+var BasicSecurityClientCode = `// BasicClientBefore returns a go-kit HTTP client option that sets the basic
+// auth credentials of the "basic" scheme in the requests made to the
+// SecuredService service.
+func BasicClientBefore(user, pass string) kithttp.ClientOption {
+	return kithttp.ClientBefore(kitauth.BasicAuthToHTTP(user, pass))
+}
+`
+
+var TokenSecurityClientCode = `// TokenClientBefore returns a go-kit HTTP client option that writes the token
+// of the "token" JWT scheme in the requests made to the SecuredService
+// service. The token is read from the context under the
+// kitjwt.JWTTokenContextKey key, for example as set by the kitjwt.NewSigner
+// middleware.
+func TokenClientBefore() kithttp.ClientOption {
+	return kithttp.ClientBefore(kitjwt.ContextToHTTP())
+}
+`
+
+var HeaderTokenSecurityClientCode = `// HeaderTokenClientBefore returns a go-kit HTTP client option that writes the
+// token of the "header_token" JWT scheme in the requests made to the
+// SecuredService service. The token is read from the context under the
+// kitjwt.JWTTokenContextKey key, for example as set by the kitjwt.NewSigner
+// middleware.
+func HeaderTokenClientBefore() kithttp.ClientOption {
+	return kithttp.ClientBefore(kitauth.JWTToHTTP("header", "X-Token"))
+}
+`
+
+var APIKeySecurityClientCode = `// APIKeyClientBefore returns a go-kit HTTP client option that writes the given
+// key of the "api_key" API key scheme in the requests made to the
+// SecuredService service.
+func APIKeyClientBefore(key string) kithttp.ClientOption {
+	return kithttp.ClientBefore(kitauth.APIKeyToHTTP("query", "key", key))
+}
+`
+
+var OAuth2SecurityClientCode = `// Oauth2ClientBefore returns a go-kit HTTP client option that writes the given
+// access token of the "oauth2" OAuth2 scheme in the requests made to the
+// SecuredService service.
+func Oauth2ClientBefore(token string) kithttp.ClientOption {
+	return kithttp.ClientBefore(kitauth.OAuth2ToHTTP(token))
+}
+`
+
+var SecureMethodGoakitErrorEncoderCode = `// EncodeSecureMethodError returns a go-kit EncodeResponseFunc suitable for
+// encoding errors returned by the SecuredService SecureMethod endpoint. The
+// errors wrapped with NewSecureMethodError are unwrapped before being encoded.
+func EncodeSecureMethodError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
+	enc := server.EncodeSecureMethodError(encoder)
+	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		// The authorization errors are not defined in the design, go-kit
+		// encodes them using their status code and headers.
+		if _, ok := err.(*kitauth.Error); ok {
+			kithttp.DefaultErrorEncoder(ctx, err, w)
+			return
+		}
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
+		enc(ctx, w, err)
+	}
+}
+`
+
+var MultiMethodGoakitErrorEncoderCode = `// EncodeMultiMethodError returns a go-kit EncodeResponseFunc suitable for
+// encoding errors returned by the SecuredService MultiMethod endpoint. The
+// errors wrapped with NewMultiMethodError are unwrapped before being encoded.
+func EncodeMultiMethodError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
+	enc := server.EncodeMultiMethodError(encoder)
+	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		// The authorization errors are not defined in the design, go-kit
+		// encodes them using their status code and headers.
+		if _, ok := err.(*kitauth.Error); ok {
+			kithttp.DefaultErrorEncoder(ctx, err, w)
+			return
+		}
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
+		enc(ctx, w, err)
+	}
+}
+`
+
+var UnsecureMethodGoakitErrorEncoderCode = `// EncodeUnsecureMethodError returns a go-kit EncodeResponseFunc suitable for
+// encoding errors returned by the SecuredService UnsecureMethod endpoint. The
+// errors wrapped with NewUnsecureMethodError are unwrapped before being
+// encoded.
+func EncodeUnsecureMethodError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) kithttp.ErrorEncoder {
+	enc := server.EncodeUnsecureMethodError(encoder)
+	return func(ctx context.Context, err error, w http.ResponseWriter) {
+		if e, ok := err.(*Error); ok {
+			err = e.Err
+		}
+		enc(ctx, w, err)
+	}
+}
+`
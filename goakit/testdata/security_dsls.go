@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/plugins/goakit/dsl"
+)
+
+var BasicAuthScheme = BasicAuthSecurity("basic")
+
+var JWTScheme = JWTSecurity("token", func() {
+	Scope("api:write", "Write access")
+})
+
+var HeaderJWTScheme = JWTSecurity("header_token", func() {
+	In("header", "X-Token")
+})
+
+var APIKeyScheme = APIKeySecurity("api_key", func() {
+	In("query", "key")
+})
+
+var OAuth2Scheme = OAuth2Security("oauth2", func() {
+	ImplicitFlow("https://example.com/oauth2/auth", "")
+	Scope("api:write", "Write access")
+})
+
+var SecurityDSL = func() {
+	Service("SecuredService", func() {
+		Security(JWTScheme, func() {
+			Scope("api:write")
+		})
+		Error("bad_request")
+		Method("SecureMethod", func() {
+			HTTP(func() {
+				GET("/")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+		Method("MultiMethod", func() {
+			Security(BasicAuthScheme, APIKeyScheme)
+			Security(HeaderJWTScheme)
+			Security(OAuth2Scheme, func() {
+				Scope("api:write")
+			})
+			HTTP(func() {
+				POST("/multi")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+		Method("UnsecureMethod", func() {
+			NoSecurity()
+			HTTP(func() {
+				GET("/unsecure")
+				Response("bad_request", StatusBadRequest)
+			})
+		})
+	})
+}
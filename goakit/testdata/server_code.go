@@ -0,0 +1,96 @@
+package testdata
+
+var SimpleMethodGoakitServerCode = `// NewSimpleMethodServer returns a go-kit HTTP server that serves the
+// SimpleService service SimpleMethod endpoint.
+func NewSimpleMethodServer(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		e,
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		EncodeSimpleMethodResponse(enc),
+		opts...,
+	)
+}
+`
+
+var WithPayloadMethodGoakitServerCode = `// NewWithPayloadMethodServer returns a go-kit HTTP server that serves the
+// WithPayloadService service WithPayloadMethod endpoint.
+func NewWithPayloadMethodServer(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		e,
+		DecodeWithPayloadMethodRequest(mux, dec),
+		EncodeWithPayloadMethodResponse(enc),
+		opts...,
+	)
+}
+`
+
+var WithErrorMethodGoakitServerCode = `// NewWithErrorMethodServer returns a go-kit HTTP server that serves the
+// WithErrorService service WithErrorMethod endpoint. The errors defined in the
+// design are encoded with EncodeWithErrorMethodError so that the responses use
+// the designed status codes, the given options may override the error encoder.
+func NewWithErrorMethodServer(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	opts = append([]kithttp.ServerOption{kithttp.ServerErrorEncoder(EncodeWithErrorMethodError(enc))}, opts...)
+	return kithttp.NewServer(
+		e,
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		EncodeWithErrorMethodResponse(enc),
+		opts...,
+	)
+}
+`
+
+var Endpoint1GoakitServerCode = `// NewEndpoint1Server returns a go-kit HTTP server that serves the
+// MultiEndpointService service Endpoint1 endpoint. The errors defined in the
+// design are encoded with EncodeEndpoint1Error so that the responses use the
+// designed status codes, the given options may override the error encoder.
+func NewEndpoint1Server(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	opts = append([]kithttp.ServerOption{kithttp.ServerErrorEncoder(EncodeEndpoint1Error(enc))}, opts...)
+	return kithttp.NewServer(
+		e,
+		DecodeEndpoint1Request(mux, dec),
+		EncodeEndpoint1Response(enc),
+		opts...,
+	)
+}
+`
+
+var Endpoint2GoakitServerCode = `// NewEndpoint2Server returns a go-kit HTTP server that serves the
+// MultiEndpointService service Endpoint2 endpoint. The errors defined in the
+// design are encoded with EncodeEndpoint2Error so that the responses use the
+// designed status codes, the given options may override the error encoder.
+func NewEndpoint2Server(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	opts = append([]kithttp.ServerOption{kithttp.ServerErrorEncoder(EncodeEndpoint2Error(enc))}, opts...)
+	return kithttp.NewServer(
+		e,
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		EncodeEndpoint2Response(enc),
+		opts...,
+	)
+}
+`
+
+var MultipartMethodGoakitServerCode = `// NewMultipartMethodServer returns a go-kit HTTP server that serves the
+// MultipartService service MultipartMethod endpoint. The multipart requests
+// are decoded with the user provided function
+// multipartServiceMultipartMethodDecoderFn.
+func NewMultipartMethodServer(e endpoint.Endpoint, mux goahttp.Muxer, multipartServiceMultipartMethodDecoderFn server.MultipartServiceMultipartMethodDecoderFunc, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		e,
+		DecodeMultipartMethodRequest(mux, multipartServiceMultipartMethodDecoderFn),
+		EncodeMultipartMethodResponse(enc),
+		opts...,
+	)
+}
+`
+
+var UnaryMethodGoakitServerCode = `// NewUnaryMethodServer returns a go-kit HTTP server that serves the
+// StreamingService service UnaryMethod endpoint.
+func NewUnaryMethodServer(e endpoint.Endpoint, mux goahttp.Muxer, dec func(*http.Request) goahttp.Decoder, enc func(context.Context, http.ResponseWriter) goahttp.Encoder, opts ...kithttp.ServerOption) *kithttp.Server {
+	return kithttp.NewServer(
+		e,
+		func(context.Context, *http.Request) (interface{}, error) { return nil, nil },
+		EncodeUnaryMethodResponse(enc),
+		opts...,
+	)
+}
+`
@@ -0,0 +1,38 @@
+package testdata
+
+var SimpleMethodGoakitServerTracingCode = `// SimpleMethodTraceServerBefore returns a go-kit HTTP server option that
+// extracts the trace context from the request headers and starts the
+// "SimpleService.SimpleMethod" span. Apply the kitendpoint.Tracing middleware
+// to the SimpleService service endpoints to finish the span.
+func SimpleMethodTraceServerBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ServerOption {
+	return kithttp.ServerBefore(kitot.HTTPToContext(tracer, "SimpleService.SimpleMethod", logger))
+}
+`
+
+var Endpoint1GoakitServerTracingCode = `// Endpoint1TraceServerBefore returns a go-kit HTTP server option that extracts
+// the trace context from the request headers and starts the
+// "MultiEndpointService.Endpoint1" span. Apply the kitendpoint.Tracing
+// middleware to the MultiEndpointService service endpoints to finish the span.
+func Endpoint1TraceServerBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ServerOption {
+	return kithttp.ServerBefore(kitot.HTTPToContext(tracer, "MultiEndpointService.Endpoint1", logger))
+}
+`
+
+var Endpoint2GoakitServerTracingCode = `// Endpoint2TraceServerBefore returns a go-kit HTTP server option that extracts
+// the trace context from the request headers and starts the
+// "MultiEndpointService.Endpoint2" span. Apply the kitendpoint.Tracing
+// middleware to the MultiEndpointService service endpoints to finish the span.
+func Endpoint2TraceServerBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ServerOption {
+	return kithttp.ServerBefore(kitot.HTTPToContext(tracer, "MultiEndpointService.Endpoint2", logger))
+}
+`
+
+var SimpleServiceGoakitClientTracingCode = `// TraceClientBefore returns a go-kit HTTP client option that injects the trace
+// context of the span held by the request context in the headers of the
+// requests made to the SimpleService service. Apply the
+// kitendpoint.ClientTracing middleware to the client endpoints to start the
+// span.
+func TraceClientBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ClientOption {
+	return kithttp.ClientBefore(kitot.ContextToHTTP(tracer, logger))
+}
+`
@@ -0,0 +1,82 @@
+package goakit
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// TracingFiles produces the files containing the go-kit HTTP server and client
+// options that propagate the trace context over the HTTP request headers.
+func TracingFiles(root *httpdesign.RootExpr) []*codegen.File {
+	fw := make([]*codegen.File, 0, 2*len(root.HTTPServices))
+	for _, svc := range root.HTTPServices {
+		fw = append(fw, serverTracingFile(svc), clientTracingFile(svc))
+	}
+	return fw
+}
+
+// serverTracingFile returns the file defining the go-kit HTTP server options
+// that extract the trace context of the requests made to the given service.
+func serverTracingFile(svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitserver", "tracing.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit HTTP server tracing", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "server", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/log"},
+			{Path: "github.com/go-kit/kit/tracing/opentracing", Name: "kitot"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "github.com/opentracing/opentracing-go", Name: "opentracing"},
+		}),
+	}
+	for _, e := range data.Endpoints {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "goakit-server-tracing",
+			Source: serverTracingT,
+			Data:   e,
+		})
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// clientTracingFile returns the file defining the go-kit HTTP client option
+// that injects the trace context in the requests made to the given service.
+func clientTracingFile(svc *httpdesign.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "kitclient", "tracing.go")
+	data := kitServiceData(httpcodegen.HTTPServices.Get(svc.Name()))
+	title := fmt.Sprintf("%s go-kit HTTP client tracing", svc.Name())
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "client", []*codegen.ImportSpec{
+			{Path: "github.com/go-kit/kit/log"},
+			{Path: "github.com/go-kit/kit/tracing/opentracing", Name: "kitot"},
+			{Path: "github.com/go-kit/kit/transport/http", Name: "kithttp"},
+			{Path: "github.com/opentracing/opentracing-go", Name: "opentracing"},
+		}),
+		{
+			Name:   "goakit-client-tracing",
+			Source: clientTracingT,
+			Data:   data,
+		},
+	}
+
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: EndpointData
+const serverTracingT = `{{ printf "%sTraceServerBefore returns a go-kit HTTP server option that extracts the trace context from the request headers and starts the \"%s.%s\" span. Apply the kitendpoint.Tracing middleware to the %s service endpoints to finish the span." .Method.VarName .ServiceName .Method.Name .ServiceName | comment }}
+func {{ .Method.VarName }}TraceServerBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ServerOption {
+	return kithttp.ServerBefore(kitot.HTTPToContext(tracer, {{ printf "%q" (printf "%s.%s" .ServiceName .Method.Name) }}, logger))
+}
+`
+
+// input: ServiceData
+const clientTracingT = `{{ printf "TraceClientBefore returns a go-kit HTTP client option that injects the trace context of the span held by the request context in the headers of the requests made to the %s service. Apply the kitendpoint.ClientTracing middleware to the client endpoints to start the span." .Service.Name | comment }}
+func TraceClientBefore(tracer opentracing.Tracer, logger log.Logger) kithttp.ClientOption {
+	return kithttp.ClientBefore(kitot.ContextToHTTP(tracer, logger))
+}
+`
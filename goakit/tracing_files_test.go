@@ -0,0 +1,38 @@
+package goakit
+
+import (
+	"testing"
+
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/goakit/testdata"
+)
+
+func TestTracingFiles(t *testing.T) {
+	cases := map[string]struct {
+		DSL        func()
+		ServerCode []string
+	}{
+		"simple-service":  {testdata.SimpleServiceDSL, []string{testdata.SimpleMethodGoakitServerTracingCode}},
+		"multi-endpoints": {testdata.MultiEndpointDSL, []string{testdata.Endpoint1GoakitServerTracingCode, testdata.Endpoint2GoakitServerTracingCode}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			fs := TracingFiles(httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected 2", len(fs))
+			}
+			testCode(t, fs[0], "goakit-server-tracing", c.ServerCode)
+		})
+	}
+}
+
+func TestClientTracingFile(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.SimpleServiceDSL)
+	fs := TracingFiles(httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	testCode(t, fs[1], "goakit-client-tracing", []string{testdata.SimpleServiceGoakitClientTracingCode})
+}
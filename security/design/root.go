@@ -0,0 +1,104 @@
+package design
+
+import (
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// Root is the design root expression.
+var Root = &RootExpr{}
+
+type (
+	// RootExpr keeps track of the security schemes and requirements defined
+	// in the design.
+	RootExpr struct {
+		// Schemes lists the security schemes in the order they are defined.
+		Schemes []*SchemeExpr
+		// Requirements lists the security requirements defined at the API,
+		// service and method levels.
+		Requirements []*SecurityExpr
+	}
+)
+
+// Register design root with eval engine.
+func init() {
+	eval.Register(Root)
+}
+
+// EvalName returns the name used in error messages.
+func (r *RootExpr) EvalName() string {
+	return "security plugin"
+}
+
+// WalkSets iterates over the security schemes and requirements.
+func (r *RootExpr) WalkSets(walk eval.SetWalker) {
+	schemes := make(eval.ExpressionSet, 0, len(r.Schemes))
+	for _, s := range r.Schemes {
+		schemes = append(schemes, s)
+	}
+	walk(schemes)
+	reqs := make(eval.ExpressionSet, 0, len(r.Requirements))
+	for _, s := range r.Requirements {
+		reqs = append(reqs, s)
+	}
+	walk(reqs)
+}
+
+// DependsOn tells the eval engine to run the goa DSL first.
+func (r *RootExpr) DependsOn() []eval.Root {
+	return []eval.Root{httpdesign.Root}
+}
+
+// Packages returns the import path to the Go packages that make
+// up the DSL. This is used to skip frames that point to files
+// in these packages when computing the location of errors.
+func (r *RootExpr) Packages() []string {
+	return []string{"goa.design/plugins/security/dsl"}
+}
+
+// Scheme returns the security scheme with the given name, nil if there
+// isn't one.
+func (r *RootExpr) Scheme(name string) *SchemeExpr {
+	for _, s := range r.Schemes {
+		if s.SchemeName == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Requirements returns the security requirements that apply to the given
+// method of the given service. Method level requirements override service
+// level requirements which override API level requirements. Requirements
+// returns nil if the method is not secured, either because there are no
+// requirements or because NoSecurity was used.
+func Requirements(svc, method string) []*SecurityExpr {
+	s := goadesign.Root.Service(svc)
+	if s == nil {
+		return nil
+	}
+	m := s.Method(method)
+	if m == nil {
+		return nil
+	}
+	for _, parent := range []eval.Expression{m, s, goadesign.Root.API} {
+		var reqs []*SecurityExpr
+		for _, r := range Root.Requirements {
+			if r.Parent == parent {
+				reqs = append(reqs, r)
+			}
+		}
+		if len(reqs) == 0 {
+			continue
+		}
+		for _, r := range reqs {
+			if len(r.Schemes) == 0 {
+				// NoSecurity
+				return nil
+			}
+		}
+		return reqs
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package design
+
+import (
+	"fmt"
+
+	"goa.design/goa/eval"
+)
+
+const (
+	// BasicAuthKind identifies a basic authentication scheme.
+	BasicAuthKind SchemeKind = iota + 1
+	// APIKeyKind identifies an API key scheme.
+	APIKeyKind
+	// OAuth2Kind identifies an OAuth2 scheme.
+	OAuth2Kind
+	// JWTKind identifies a JWT scheme.
+	JWTKind
+)
+
+const (
+	// AuthorizationCodeFlowKind identifies an OAuth2 authorization code flow.
+	AuthorizationCodeFlowKind FlowKind = iota + 1
+	// ImplicitFlowKind identifies an OAuth2 implicit flow.
+	ImplicitFlowKind
+	// PasswordFlowKind identifies an OAuth2 resource owner password flow.
+	PasswordFlowKind
+	// ClientCredentialsFlowKind identifies an OAuth2 client credentials flow.
+	ClientCredentialsFlowKind
+)
+
+const (
+	// HeaderLocation indicates that the credentials are read from a request
+	// header.
+	HeaderLocation = "header"
+	// QueryLocation indicates that the credentials are read from a request
+	// query string parameter.
+	QueryLocation = "query"
+)
+
+type (
+	// SchemeKind is the type of a security scheme.
+	SchemeKind int
+
+	// FlowKind is the type of an OAuth2 flow.
+	FlowKind int
+
+	// SchemeExpr describes a security scheme.
+	SchemeExpr struct {
+		// Kind is the scheme kind.
+		Kind SchemeKind
+		// SchemeName is the name of the scheme.
+		SchemeName string
+		// In is the location of the credentials, one of HeaderLocation or
+		// QueryLocation. It only applies to the API key and JWT schemes.
+		In string
+		// Key is the name of the header or query string parameter that
+		// contains the credentials. It only applies to the API key and JWT
+		// schemes.
+		Key string
+		// Scopes lists the scopes defined by the scheme. It only applies
+		// to the OAuth2 and JWT schemes.
+		Scopes []*ScopeExpr
+		// Flows lists the OAuth2 flows supported by the scheme. It only
+		// applies to the OAuth2 schemes.
+		Flows []*FlowExpr
+	}
+
+	// ScopeExpr describes a scope defined by a security scheme.
+	ScopeExpr struct {
+		// Name of the scope.
+		Name string
+		// Description of the scope.
+		Description string
+	}
+
+	// FlowExpr describes an OAuth2 flow.
+	FlowExpr struct {
+		// Kind is the flow kind.
+		Kind FlowKind
+		// AuthorizationURL is the authorization URL used by the
+		// authorization code and implicit flows.
+		AuthorizationURL string
+		// TokenURL is the token URL used by the authorization code,
+		// password and client credentials flows.
+		TokenURL string
+		// RefreshURL is the URL used to refresh tokens.
+		RefreshURL string
+	}
+
+	// SecurityExpr describes a security requirement. All the schemes of a
+	// requirement must be validated for the request to be authorized. A
+	// requirement with no scheme indicates that the parent expression does
+	// not require authorization.
+	SecurityExpr struct {
+		// Schemes lists the schemes that must all be validated.
+		Schemes []*SchemeExpr
+		// Scopes lists the required scopes.
+		Scopes []string
+		// Parent expression, APIExpr, ServiceExpr or MethodExpr.
+		Parent eval.Expression
+	}
+)
+
+// String returns the name of the scheme kind as used in the security package
+// function and type names.
+func (k SchemeKind) String() string {
+	switch k {
+	case BasicAuthKind:
+		return "BasicAuth"
+	case APIKeyKind:
+		return "APIKey"
+	case OAuth2Kind:
+		return "OAuth2"
+	case JWTKind:
+		return "JWT"
+	}
+	return ""
+}
+
+// String returns the name of the flow kind.
+func (k FlowKind) String() string {
+	switch k {
+	case AuthorizationCodeFlowKind:
+		return "authorization_code"
+	case ImplicitFlowKind:
+		return "implicit"
+	case PasswordFlowKind:
+		return "password"
+	case ClientCredentialsFlowKind:
+		return "client_credentials"
+	}
+	return ""
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (s *SchemeExpr) EvalName() string {
+	return fmt.Sprintf("%s security scheme %q", s.Kind, s.SchemeName)
+}
+
+// HasScopes returns true if the scheme kind supports scopes.
+func (s *SchemeExpr) HasScopes() bool {
+	return s.Kind == OAuth2Kind || s.Kind == JWTKind
+}
+
+// Scope returns the scope with the given name, nil if there isn't one.
+func (s *SchemeExpr) Scope(name string) *ScopeExpr {
+	for _, sc := range s.Scopes {
+		if sc.Name == name {
+			return sc
+		}
+	}
+	return nil
+}
+
+// Validate ensures the scheme expression is valid.
+func (s *SchemeExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if s.Kind == APIKeyKind && s.Key == "" {
+		verr.Add(s, "API key location must be defined with In")
+	}
+	if s.In != "" && s.In != HeaderLocation && s.In != QueryLocation {
+		verr.Add(s, "invalid credentials location %q, must be one of %q or %q", s.In, HeaderLocation, QueryLocation)
+	}
+	if s.Kind == OAuth2Kind && len(s.Flows) == 0 {
+		verr.Add(s, "OAuth2 scheme must define at least one flow")
+	}
+	return verr
+}
+
+// Finalize sets the default location of the JWT credentials.
+func (s *SchemeExpr) Finalize() {
+	if s.Kind == JWTKind && s.Key == "" {
+		s.In = HeaderLocation
+		s.Key = "Authorization"
+	}
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (s *SecurityExpr) EvalName() string {
+	var suffix string
+	if s.Parent != nil {
+		suffix = fmt.Sprintf(" of %s", s.Parent.EvalName())
+	}
+	return "security requirement" + suffix
+}
+
+// Validate ensures the required scopes are defined by the requirement
+// schemes.
+func (s *SecurityExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	for _, scope := range s.Scopes {
+		found := false
+		for _, sc := range s.Schemes {
+			if sc.HasScopes() && sc.Scope(scope) != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			verr.Add(s, "scope %q is not defined by any of the requirement OAuth2 or JWT schemes", scope)
+		}
+	}
+	return verr
+}
@@ -0,0 +1,353 @@
+// Package dsl defines the security plugin DSL. It replaces the goa core
+// security DSL: the package aliases the goa HTTP DSL except for the core
+// security functions and defines its own Security, NoSecurity and security
+// scheme functions instead. The package cannot be dot-imported together with
+// the dsl package of another plugin as both alias the goa HTTP DSL, the
+// goa.design/plugins/goakit/dsl package exposes the security plugin DSL itself.
+package dsl
+
+import (
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	"goa.design/plugins/security/design"
+)
+
+// BasicAuthSecurity defines a basic authentication security scheme. The
+// credentials are read from the request Authorization header.
+//
+// BasicAuthSecurity is a top level DSL.
+//
+// BasicAuthSecurity takes a name as first argument and an optional DSL as
+// second argument.
+//
+// Example:
+//
+//    var Basic = BasicAuthSecurity("basic")
+//
+func BasicAuthSecurity(name string, fn ...func()) *design.SchemeExpr {
+	return newScheme(design.BasicAuthKind, name, fn...)
+}
+
+// APIKeySecurity defines an API key security scheme. The location of the key
+// must be defined with In.
+//
+// APIKeySecurity is a top level DSL.
+//
+// APIKeySecurity takes a name as first argument and a DSL as second argument.
+//
+// Example:
+//
+//    var APIKey = APIKeySecurity("api_key", func() {
+//        In("header", "X-API-Key") // Read key from X-API-Key header
+//    })
+//
+func APIKeySecurity(name string, fn ...func()) *design.SchemeExpr {
+	return newScheme(design.APIKeyKind, name, fn...)
+}
+
+// OAuth2Security defines an OAuth2 security scheme. The access token is read
+// from the request Authorization header using the bearer scheme. The DSL
+// lists the flows supported by the authorization server and the scopes that
+// may be required by the API endpoints.
+//
+// OAuth2Security is a top level DSL.
+//
+// OAuth2Security takes a name as first argument and a DSL as second argument.
+//
+// Example:
+//
+//    var OAuth2 = OAuth2Security("oauth2", func() {
+//        AuthorizationCodeFlow("/authorization", "/token", "/refresh")
+//        Scope("api:write", "Read and write access")
+//        Scope("api:read", "Read-only access")
+//    })
+//
+func OAuth2Security(name string, fn ...func()) *design.SchemeExpr {
+	return newScheme(design.OAuth2Kind, name, fn...)
+}
+
+// JWTSecurity defines a JSON Web Token security scheme. The token is read from
+// the request Authorization header using the bearer scheme unless a different
+// location is defined with In. The DSL lists the scopes that may be required
+// by the API endpoints.
+//
+// JWTSecurity is a top level DSL.
+//
+// JWTSecurity takes a name as first argument and an optional DSL as second
+// argument.
+//
+// Example:
+//
+//    var JWT = JWTSecurity("jwt", func() {
+//        Scope("api:read", "Read-only access")
+//    })
+//
+func JWTSecurity(name string, fn ...func()) *design.SchemeExpr {
+	return newScheme(design.JWTKind, name, fn...)
+}
+
+// In defines the location of the credentials of an API key or JWT security
+// scheme. The location is one of "header" or "query".
+//
+// In must appear in APIKeySecurity or JWTSecurity.
+//
+// In takes the location as first argument and the name of the header or query
+// string parameter as second argument.
+//
+// Example:
+//
+//    var APIKey = APIKeySecurity("api_key", func() {
+//        In("query", "key") // Read key from "key" query string parameter
+//    })
+//
+func In(location, name string) {
+	s, ok := eval.Current().(*design.SchemeExpr)
+	if !ok || (s.Kind != design.APIKeyKind && s.Kind != design.JWTKind) {
+		eval.IncompatibleDSL()
+		return
+	}
+	s.In = location
+	s.Key = name
+}
+
+// AuthorizationCodeFlow defines an authorizationCode OAuth2 flow as described
+// in section 1.3.1 of RFC 6749.
+//
+// AuthorizationCodeFlow must be used in OAuth2Security.
+//
+// AuthorizationCodeFlow accepts three arguments: the authorization, token and
+// refresh URLs.
+func AuthorizationCodeFlow(authorizationURL, tokenURL, refreshURL string) {
+	addFlow(&design.FlowExpr{
+		Kind:             design.AuthorizationCodeFlowKind,
+		AuthorizationURL: authorizationURL,
+		TokenURL:         tokenURL,
+		RefreshURL:       refreshURL,
+	})
+}
+
+// ImplicitFlow defines an implicit OAuth2 flow as described in section 1.3.2
+// of RFC 6749.
+//
+// ImplicitFlow must be used in OAuth2Security.
+//
+// ImplicitFlow accepts two arguments: the authorization and refresh URLs.
+func ImplicitFlow(authorizationURL, refreshURL string) {
+	addFlow(&design.FlowExpr{
+		Kind:             design.ImplicitFlowKind,
+		AuthorizationURL: authorizationURL,
+		RefreshURL:       refreshURL,
+	})
+}
+
+// PasswordFlow defines an Resource Owner Password Credentials OAuth2 flow as
+// described in section 1.3.3 of RFC 6749.
+//
+// PasswordFlow must be used in OAuth2Security.
+//
+// PasswordFlow accepts two arguments: the token and refresh URLs.
+func PasswordFlow(tokenURL, refreshURL string) {
+	addFlow(&design.FlowExpr{
+		Kind:       design.PasswordFlowKind,
+		TokenURL:   tokenURL,
+		RefreshURL: refreshURL,
+	})
+}
+
+// ClientCredentialsFlow defines an clientCredentials OAuth2 flow as described
+// in section 1.3.4 of RFC 6749.
+//
+// ClientCredentialsFlow must be used in OAuth2Security.
+//
+// ClientCredentialsFlow accepts two arguments: the token and refresh URLs.
+func ClientCredentialsFlow(tokenURL, refreshURL string) {
+	addFlow(&design.FlowExpr{
+		Kind:       design.ClientCredentialsFlowKind,
+		TokenURL:   tokenURL,
+		RefreshURL: refreshURL,
+	})
+}
+
+// Scope has two uses: in OAuth2Security or JWTSecurity it defines a scope
+// supported by the scheme. In Security it lists required scopes.
+//
+// Scope must appear in OAuth2Security, JWTSecurity or Security.
+//
+// Scope accepts one or two arguments: the first argument is the scope name and
+// when used in OAuth2Security or JWTSecurity the second argument is a
+// description.
+//
+// Example:
+//
+//    var JWT = JWTSecurity("JWT", func() {
+//        Scope("api:read", "Read access")
+//        Scope("api:write", "Write access")
+//    })
+//
+//    Method("secured", func() {
+//        Security(JWT, func() {
+//            Scope("api:read") // Required scope for auth
+//        })
+//    })
+//
+func Scope(name string, desc ...string) {
+	switch current := eval.Current().(type) {
+	case *design.SecurityExpr:
+		if len(desc) > 0 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		current.Scopes = append(current.Scopes, name)
+	case *design.SchemeExpr:
+		if !current.HasScopes() {
+			eval.IncompatibleDSL()
+			return
+		}
+		if len(desc) > 1 {
+			eval.ReportError("too many arguments")
+			return
+		}
+		d := "no description"
+		if len(desc) == 1 {
+			d = desc[0]
+		}
+		current.Scopes = append(current.Scopes, &design.ScopeExpr{Name: name, Description: d})
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// Security defines authentication requirements to access the API, a service
+// or a service method.
+//
+// The requirement refers to one or more OAuth2Security, BasicAuthSecurity,
+// APIKeySecurity or JWTSecurity security scheme. If the schemes include a
+// OAuth2Security or JWTSecurity scheme then required scopes may be listed by
+// name in the Security DSL. All the listed schemes must be validated by the
+// client for the request to be authorized. Security may appear multiple times
+// in the same scope in which case the client may validate any one of the
+// requirements for the request to be authorized.
+//
+// Security must appear in an API, Service or Method expression.
+//
+// Security accepts an arbitrary number of security schemes as argument
+// specified by name or by reference and an optional DSL function as last
+// argument.
+//
+// Example:
+//
+//    var _ = Service("calculator", func() {
+//        // Accept either basic auth or JWT with "api:read" scope.
+//        Security(Basic)
+//        Security("jwt", func() {
+//            Scope("api:read")
+//        })
+//
+//        Method("add", func() {
+//            // Require both basic auth and JWT with "api:write" scope.
+//            Security(Basic, JWT, func() {
+//                Scope("api:write")
+//            })
+//        })
+//    })
+//
+func Security(args ...interface{}) {
+	var dsl func()
+	{
+		if len(args) > 0 {
+			if d, ok := args[len(args)-1].(func()); ok {
+				args = args[:len(args)-1]
+				dsl = d
+			}
+		}
+	}
+	current := eval.Current()
+	switch current.(type) {
+	case *goadesign.APIExpr, *goadesign.ServiceExpr, *goadesign.MethodExpr:
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(args) == 0 {
+		eval.ReportError("missing security scheme")
+		return
+	}
+	sec := &design.SecurityExpr{Parent: current}
+	for _, arg := range args {
+		switch val := arg.(type) {
+		case *design.SchemeExpr:
+			sec.Schemes = append(sec.Schemes, val)
+		case string:
+			s := design.Root.Scheme(val)
+			if s == nil {
+				eval.ReportError("security scheme %q not found", val)
+				return
+			}
+			sec.Schemes = append(sec.Schemes, s)
+		default:
+			eval.ReportError("invalid security scheme argument %#v, must be a security scheme or a security scheme name", arg)
+			return
+		}
+	}
+	if dsl != nil {
+		if !eval.Execute(dsl, sec) {
+			return
+		}
+	}
+	design.Root.Requirements = append(design.Root.Requirements, sec)
+}
+
+// NoSecurity removes the need for an endpoint to perform authorization.
+//
+// NoSecurity must appear in a Service or Method expression.
+//
+// Example:
+//
+//    Method("health-check", func() {
+//        NoSecurity()
+//    })
+//
+func NoSecurity() {
+	current := eval.Current()
+	switch current.(type) {
+	case *goadesign.ServiceExpr, *goadesign.MethodExpr:
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	design.Root.Requirements = append(design.Root.Requirements, &design.SecurityExpr{Parent: current})
+}
+
+// newScheme creates and registers a security scheme of the given kind.
+func newScheme(kind design.SchemeKind, name string, fn ...func()) *design.SchemeExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if design.Root.Scheme(name) != nil {
+		eval.ReportError("security scheme %q is already defined", name)
+		return nil
+	}
+	if len(fn) > 1 {
+		eval.ReportError("too many arguments")
+		return nil
+	}
+	s := &design.SchemeExpr{Kind: kind, SchemeName: name}
+	if len(fn) == 1 {
+		if !eval.Execute(fn[0], s) {
+			return nil
+		}
+	}
+	design.Root.Schemes = append(design.Root.Schemes, s)
+	return s
+}
+
+// addFlow adds the given flow to the current OAuth2 scheme.
+func addFlow(f *design.FlowExpr) {
+	s, ok := eval.Current().(*design.SchemeExpr)
+	if !ok || s.Kind != design.OAuth2Kind {
+		eval.IncompatibleDSL()
+		return
+	}
+	s.Flows = append(s.Flows, f)
+}
@@ -0,0 +1,284 @@
+package security
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/security/design"
+)
+
+// ServicesData holds the all the ServiceData indexed by service name.
+var ServicesData = make(map[string]*ServiceData)
+
+type (
+	// ServiceData contains the data necessary to generate the authorization
+	// code of a service.
+	ServiceData struct {
+		// Name is the name of the service.
+		Name string
+		// Schemes lists the security schemes used by the service endpoints.
+		Schemes []*design.SchemeExpr
+		// Endpoints lists the secured endpoints.
+		Endpoints []*EndpointData
+	}
+
+	// EndpointData contains the data necessary to generate the
+	// authorization code of an endpoint.
+	EndpointData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// Method is the name of the method.
+		Method string
+		// Authorizer is the name of the function that wraps the endpoint
+		// HTTP handler to authorize the requests.
+		Authorizer string
+		// Requirements lists the security requirements of the endpoint, any
+		// one of them must be validated for the request to be authorized.
+		Requirements []*design.SecurityExpr
+	}
+)
+
+const pluginName = "security"
+
+// Register the plugin Generator functions. The plugin runs last so that the
+// handlers of the secured endpoints are already wrapped by the other plugins
+// (e.g. CORS): the authorization handler is the innermost one so that the
+// handlers added by the other plugins run first, for example to write the CORS
+// headers of the responses to unauthorized requests.
+func init() {
+	codegen.RegisterPluginLast(pluginName, "gen", Generate)
+}
+
+// Generate produces server code that extracts the credentials from the
+// requests made to the secured endpoints and calls the user provided
+// authorizer functions before the endpoints run.
+func Generate(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, root := range roots {
+		switch r := root.(type) {
+		case *httpdesign.RootExpr:
+			for _, s := range r.HTTPServices {
+				name := s.Name()
+				ServicesData[name] = BuildServiceData(name)
+			}
+			for _, f := range files {
+				ServerSecurity(f)
+			}
+		}
+	}
+	return files, nil
+}
+
+// BuildServiceData builds the data needed to render the authorization code of
+// the given service.
+func BuildServiceData(name string) *ServiceData {
+	data := ServiceData{Name: name}
+	svc := goadesign.Root.Service(name)
+	if svc == nil {
+		return &data
+	}
+	used := make(map[*design.SchemeExpr]bool)
+	for _, m := range svc.Methods {
+		reqs := design.Requirements(name, m.Name)
+		if len(reqs) == 0 {
+			continue
+		}
+		data.Endpoints = append(data.Endpoints, &EndpointData{
+			ServiceName:  name,
+			Method:       m.Name,
+			Authorizer:   "authorize" + codegen.Goify(m.Name, true),
+			Requirements: reqs,
+		})
+		for _, r := range reqs {
+			for _, s := range r.Schemes {
+				used[s] = true
+			}
+		}
+	}
+	for _, s := range design.Root.Schemes {
+		if used[s] {
+			data.Schemes = append(data.Schemes, s)
+		}
+	}
+	return &data
+}
+
+// Endpoint returns the data of the secured endpoint with the given method
+// name, nil if the endpoint is not secured.
+func (d *ServiceData) Endpoint(method string) *EndpointData {
+	for _, e := range d.Endpoints {
+		if e.Method == method {
+			return e
+		}
+	}
+	return nil
+}
+
+// ServerSecurity updates the HTTP server file so that the handlers of the
+// secured endpoints authorize the requests before calling the endpoints.
+func ServerSecurity(f *codegen.File) {
+	if filepath.Base(f.Path) != "server.go" {
+		return
+	}
+
+	var svcData *ServiceData
+	for _, s := range f.Section("server-struct") {
+		data := s.Data.(*httpcodegen.ServiceData)
+		svcData = ServicesData[data.Service.Name]
+		if svcData == nil || len(svcData.Endpoints) == 0 {
+			return
+		}
+		codegen.AddImport(f.SectionTemplates[0],
+			&codegen.ImportSpec{Path: "goa.design/plugins/security"})
+
+		fm := codegen.TemplateFuncs()
+		fm["schemeLiteral"] = schemeLiteral
+		fm["isBasicAuth"] = isBasicAuth
+		f.SectionTemplates = append(f.SectionTemplates, &codegen.SectionTemplate{
+			Name:    "security-authorizers",
+			Source:  authorizersT,
+			Data:    svcData,
+			FuncMap: fm,
+		})
+		for _, e := range svcData.Endpoints {
+			f.SectionTemplates = append(f.SectionTemplates, &codegen.SectionTemplate{
+				Name:    "security-authorize",
+				Source:  authorizeT,
+				Data:    e,
+				FuncMap: fm,
+			})
+		}
+	}
+	if svcData == nil {
+		return
+	}
+	for _, s := range f.Section("server-handler") {
+		data := s.Data.(*httpcodegen.EndpointData)
+		e := svcData.Endpoint(data.Method.Name)
+		if e == nil {
+			continue
+		}
+		if strings.Contains(s.Source, "(h).(http.HandlerFunc)") {
+			// The handler is already wrapped by another plugin (e.g. CORS).
+			s.Source = strings.Replace(s.Source, "(h).(http.HandlerFunc)", "("+e.Authorizer+"(h)).(http.HandlerFunc)", -1)
+		} else {
+			s.Source = strings.Replace(s.Source, "h.(http.HandlerFunc)", e.Authorizer+"(h).(http.HandlerFunc)", -1)
+		}
+	}
+}
+
+// schemeLiteral returns the Go literal that initializes the security package
+// scheme struct corresponding to the given scheme. The required scopes are
+// the requirement scopes defined by the scheme.
+func schemeLiteral(s *design.SchemeExpr, req *design.SecurityExpr) string {
+	fields := []string{fmt.Sprintf("Name: %q", s.SchemeName)}
+	if s.Kind == design.APIKeyKind || s.Kind == design.JWTKind {
+		fields = append(fields, fmt.Sprintf("In: %q", s.In), fmt.Sprintf("Key: %q", s.Key))
+	}
+	if s.HasScopes() {
+		var scopes, required []string
+		for _, sc := range s.Scopes {
+			scopes = append(scopes, sc.Name)
+		}
+		for _, r := range req.Scopes {
+			if s.Scope(r) != nil {
+				required = append(required, r)
+			}
+		}
+		if len(scopes) > 0 {
+			fields = append(fields, "Scopes: "+stringSlice(scopes))
+		}
+		if len(required) > 0 {
+			fields = append(fields, "RequiredScopes: "+stringSlice(required))
+		}
+	}
+	return fmt.Sprintf("&security.%sScheme{%s}", s.Kind, strings.Join(fields, ", "))
+}
+
+// isBasicAuth returns true if the given scheme is a basic auth scheme.
+func isBasicAuth(s *design.SchemeExpr) bool {
+	return s.Kind == design.BasicAuthKind
+}
+
+// stringSlice returns the Go literal of the given string slice.
+func stringSlice(vals []string) string {
+	quoted := make([]string, len(vals))
+	for i, v := range vals {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// Data: ServiceData
+var authorizersT = `{{ printf "Authorizers lists the functions used to authorize the requests made to the secured %s service endpoints." .Name | comment }}
+type Authorizers struct {
+{{- range .Schemes }}
+	// {{ goify .SchemeName true }} authorizes the requests secured with the {{ printf "%q" .SchemeName }} scheme.
+	{{ goify .SchemeName true }} security.{{ .Kind }}Func
+{{- end }}
+	// ErrorHandler writes the response of the requests that fail
+	// authorization, security.WriteError is used if nil.
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+}
+
+// authorizersKey is the request context key used to store the authorizers.
+type authorizersKey struct{}
+
+{{ printf "Authorize returns a HTTP handler that makes the given authorizers available to the handlers of the secured %s service endpoints before calling h. The requests made to the secured endpoints are rejected if h is not wrapped with Authorize." .Name | comment }}
+func Authorize(h http.Handler, a *Authorizers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authorizersKey{}, a)))
+	})
+}
+
+// contextAuthorizers returns the authorizers stored in the given context.
+func contextAuthorizers(ctx context.Context) *Authorizers {
+	if a, ok := ctx.Value(authorizersKey{}).(*Authorizers); ok && a != nil {
+		return a
+	}
+	return &Authorizers{}
+}
+{{- range .Schemes }}
+
+{{ printf "%sAuthorizer calls the %s function of the authorizers stored in the request context." (goify .SchemeName false) (goify .SchemeName true) | comment }}
+func {{ goify .SchemeName false }}Authorizer(ctx context.Context, {{ if isBasicAuth . }}user, pass{{ else }}cred{{ end }} string, s *security.{{ .Kind }}Scheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).{{ goify .SchemeName true }}
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, {{ if isBasicAuth . }}user, pass{{ else }}cred{{ end }}, s)
+}
+{{- end }}
+`
+
+// Data: EndpointData
+var authorizeT = `{{ printf "%s returns a HTTP handler that authorizes the requests made to the %s endpoint of the %s service before calling h." .Authorizer .Method .ServiceName | comment }}
+func {{ .Authorizer }}(h http.Handler) http.Handler {
+	authorize := security.Any(
+	{{- range $req := .Requirements }}
+		security.All(
+		{{- range $req.Schemes }}
+			security.{{ .Kind }}({{ goify .SchemeName false }}Authorizer, {{ schemeLiteral . $req }}),
+		{{- end }}
+		),
+	{{- end }}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authorize(r.Context(), r)
+		if err != nil {
+			eh := contextAuthorizers(r.Context()).ErrorHandler
+			if eh == nil {
+				eh = security.WriteError
+			}
+			eh(w, r, err)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+`
@@ -0,0 +1,133 @@
+package security
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/plugins/security/design"
+	"goa.design/plugins/security/testdata"
+)
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		Name            string
+		DSL             func()
+		AuthorizersCode string
+		AuthorizeCode   string
+		Unsecured       []string
+	}{
+		{"basic-auth", testdata.BasicAuthDSL, testdata.BasicAuthAuthorizersCode, testdata.BasicAuthAuthorizeCode, nil},
+		{"multi-requirements", testdata.MultiRequirementsDSL, testdata.MultiRequirementsAuthorizersCode, testdata.MultiRequirementsAuthorizeCode, []string{"Unsecure"}},
+		{"api-security", testdata.APISecurityDSL, testdata.APISecurityAuthorizersCode, testdata.APISecurityAuthorizeCode, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if err := runDSL(t, c.DSL); err != nil {
+				t.Fatalf("invalid design: %s", err)
+			}
+			fs := httpcodegen.ServerFiles("", httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			Generate("", []eval.Root{httpdesign.Root}, fs)
+			for _, f := range fs {
+				if filepath.Base(f.Path) != "server.go" {
+					continue
+				}
+				testCode(t, f, "security-authorizers", c.AuthorizersCode)
+				testCode(t, f, "security-authorize", c.AuthorizeCode)
+				for _, s := range f.Section("server-handler") {
+					data := s.Data.(*httpcodegen.EndpointData)
+					authorizer := "authorize" + data.Method.VarName + "(h)"
+					secured := !contains(c.Unsecured, data.Method.Name)
+					if secured && !strings.Contains(s.Source, authorizer) {
+						t.Errorf("server-handler %s: invalid code, expected to contain %s", data.Method.Name, authorizer)
+					}
+					if !secured && strings.Contains(s.Source, authorizer) {
+						t.Errorf("server-handler %s: invalid code, expected not to contain %s", data.Method.Name, authorizer)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateNoSecurity(t *testing.T) {
+	if err := runDSL(t, testdata.NoSecurityDSL); err != nil {
+		t.Fatalf("invalid design: %s", err)
+	}
+	fs := httpcodegen.ServerFiles("", httpdesign.Root)
+	Generate("", []eval.Root{httpdesign.Root}, fs)
+	for _, f := range fs {
+		if s := f.Section("security-authorizers"); len(s) > 0 {
+			t.Errorf("%s: got %d authorizers sections, expected none", f.Path, len(s))
+		}
+		for _, s := range f.Section("server-handler") {
+			if strings.Contains(s.Source, "authorize") {
+				t.Errorf("server-handler: invalid code, expected no authorizer")
+			}
+		}
+	}
+}
+
+func TestGenerateInvalidScope(t *testing.T) {
+	err := runDSL(t, testdata.InvalidScopeDSL)
+	if err == nil {
+		t.Fatal("got no error, expected an undefined scope error")
+	}
+	if exp := `scope "api:admin" is not defined`; !strings.Contains(err.Error(), exp) {
+		t.Errorf("got error %q, expected it to contain %q", err, exp)
+	}
+}
+
+// runDSL runs the given DSL then finalizes and validates the security schemes
+// and requirements. RunHTTPDSL only runs the goa roots, the security plugin
+// root is neither finalized nor validated otherwise.
+func runDSL(t *testing.T, dsl func()) error {
+	design.Root.Requirements = nil
+	httpcodegen.RunHTTPDSL(t, dsl)
+	for _, s := range design.Root.Schemes {
+		s.Finalize()
+	}
+	var msgs []string
+	for _, s := range design.Root.Schemes {
+		if verr := s.Validate(); len(verr.Errors) > 0 {
+			msgs = append(msgs, verr.Error())
+		}
+	}
+	for _, r := range design.Root.Requirements {
+		if verr := r.Validate(); len(verr.Errors) > 0 {
+			msgs = append(msgs, verr.Error())
+		}
+	}
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "\n"))
+	}
+	return nil
+}
+
+func testCode(t *testing.T, file *codegen.File, section, expCode string) {
+	sections := file.Section(section)
+	if len(sections) < 1 {
+		t.Fatalf("%s: got %d sections, expected at least 1", section, len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != expCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, expCode))
+	}
+}
+
+func contains(vals []string, s string) bool {
+	for _, v := range vals {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
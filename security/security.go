@@ -0,0 +1,263 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Authorizer authorizes a request. It returns the context used to
+	// handle the request or an error if the request is not authorized.
+	Authorizer func(ctx context.Context, r *http.Request) (context.Context, error)
+
+	// BasicAuthFunc authorizes the requests secured with a basic auth
+	// scheme given the username and password read from the request. It
+	// returns the context used to handle the request or an error if the
+	// credentials are invalid.
+	BasicAuthFunc func(ctx context.Context, user, pass string, s *BasicAuthScheme) (context.Context, error)
+
+	// APIKeyFunc authorizes the requests secured with an API key scheme
+	// given the key read from the request. It returns the context used to
+	// handle the request or an error if the key is invalid.
+	APIKeyFunc func(ctx context.Context, key string, s *APIKeyScheme) (context.Context, error)
+
+	// OAuth2Func authorizes the requests secured with an OAuth2 scheme given
+	// the access token read from the request. It returns the context used to
+	// handle the request or an error if the token is invalid or does not
+	// grant the required scopes.
+	OAuth2Func func(ctx context.Context, token string, s *OAuth2Scheme) (context.Context, error)
+
+	// JWTFunc authorizes the requests secured with a JWT scheme given the
+	// token read from the request. It returns the context used to handle the
+	// request or an error if the token is invalid or does not grant the
+	// required scopes.
+	JWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// BasicAuthScheme describes a basic auth security scheme.
+	BasicAuthScheme struct {
+		// Name is the scheme name.
+		Name string
+	}
+
+	// APIKeyScheme describes an API key security scheme.
+	APIKeyScheme struct {
+		// Name is the scheme name.
+		Name string
+		// In is the location of the key, one of "header" or "query".
+		In string
+		// Key is the name of the header or query string parameter
+		// containing the key.
+		Key string
+	}
+
+	// OAuth2Scheme describes an OAuth2 security scheme.
+	OAuth2Scheme struct {
+		// Name is the scheme name.
+		Name string
+		// Scopes lists the scopes defined by the scheme.
+		Scopes []string
+		// RequiredScopes lists the scopes required by the endpoint.
+		RequiredScopes []string
+	}
+
+	// JWTScheme describes a JWT security scheme.
+	JWTScheme struct {
+		// Name is the scheme name.
+		Name string
+		// In is the location of the token, one of "header" or "query".
+		In string
+		// Key is the name of the header or query string parameter
+		// containing the token.
+		Key string
+		// Scopes lists the scopes defined by the scheme.
+		Scopes []string
+		// RequiredScopes lists the scopes required by the endpoint.
+		RequiredScopes []string
+	}
+
+	// Error is the error returned when a request is not authorized.
+	Error struct {
+		// Scheme is the name of the scheme that failed authorization.
+		Scheme string
+		// Message describes the error.
+		Message string
+		// Status is the HTTP status code of the response.
+		Status int
+	}
+
+	// statusCoder is implemented by errors that define the HTTP status
+	// code of the response.
+	statusCoder interface {
+		StatusCode() int
+	}
+)
+
+// Unauthorized returns an error that produces a 401 Unauthorized response.
+func Unauthorized(scheme, format string, vals ...interface{}) error {
+	return &Error{Scheme: scheme, Message: fmt.Sprintf(format, vals...), Status: http.StatusUnauthorized}
+}
+
+// Forbidden returns an error that produces a 403 Forbidden response.
+func Forbidden(scheme, format string, vals ...interface{}) error {
+	return &Error{Scheme: scheme, Message: fmt.Sprintf(format, vals...), Status: http.StatusForbidden}
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Scheme, e.Message)
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (e *Error) StatusCode() int {
+	return e.Status
+}
+
+// HasScopes returns a Forbidden error if granted does not contain all the
+// required scopes, nil otherwise.
+func HasScopes(scheme string, granted, required []string) error {
+	var missing []string
+	for _, r := range required {
+		found := false
+		for _, g := range granted {
+			if g == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) > 0 {
+		return Forbidden(scheme, "missing scopes %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// All returns an authorizer that authorizes the requests for which all the
+// given authorizers succeed. The context returned by each authorizer is given
+// to the next.
+func All(authorizers ...Authorizer) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		var err error
+		for _, a := range authorizers {
+			if ctx, err = a(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		return ctx, nil
+	}
+}
+
+// Any returns an authorizer that authorizes the requests for which any of the
+// given authorizers succeed. The authorizers are tried in order, the error
+// returned by the first authorizer is returned if none succeed.
+func Any(authorizers ...Authorizer) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		var first error
+		for _, a := range authorizers {
+			actx, err := a(ctx, r)
+			if err == nil {
+				return actx, nil
+			}
+			if first == nil {
+				first = err
+			}
+		}
+		if first != nil {
+			return nil, first
+		}
+		return ctx, nil
+	}
+}
+
+// BasicAuth returns an authorizer that reads the basic auth credentials from
+// the request Authorization header and calls fn. The authorizer fails if fn
+// is nil.
+func BasicAuth(fn BasicAuthFunc, s *BasicAuthScheme) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		if fn == nil {
+			return nil, Unauthorized(s.Name, "no authorizer")
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok {
+			return nil, Unauthorized(s.Name, "missing basic auth credentials")
+		}
+		return fn(ctx, user, pass, s)
+	}
+}
+
+// APIKey returns an authorizer that reads the API key from the request and
+// calls fn. The authorizer fails if fn is nil.
+func APIKey(fn APIKeyFunc, s *APIKeyScheme) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		if fn == nil {
+			return nil, Unauthorized(s.Name, "no authorizer")
+		}
+		key := credentials(r, s.In, s.Key)
+		if key == "" {
+			return nil, Unauthorized(s.Name, "missing API key")
+		}
+		return fn(ctx, key, s)
+	}
+}
+
+// OAuth2 returns an authorizer that reads the bearer access token from the
+// request Authorization header and calls fn. The authorizer fails if fn is
+// nil.
+func OAuth2(fn OAuth2Func, s *OAuth2Scheme) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		if fn == nil {
+			return nil, Unauthorized(s.Name, "no authorizer")
+		}
+		token := credentials(r, "header", "Authorization")
+		if token == "" {
+			return nil, Unauthorized(s.Name, "missing access token")
+		}
+		return fn(ctx, token, s)
+	}
+}
+
+// JWT returns an authorizer that reads the token from the request and calls
+// fn. The authorizer fails if fn is nil.
+func JWT(fn JWTFunc, s *JWTScheme) Authorizer {
+	return func(ctx context.Context, r *http.Request) (context.Context, error) {
+		if fn == nil {
+			return nil, Unauthorized(s.Name, "no authorizer")
+		}
+		token := credentials(r, s.In, s.Key)
+		if token == "" {
+			return nil, Unauthorized(s.Name, "missing token")
+		}
+		return fn(ctx, token, s)
+	}
+}
+
+// WriteError writes the response corresponding to the given authorization
+// error. The response status code is 401 Unauthorized unless the error
+// implements the StatusCode method.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusUnauthorized
+	if sc, ok := err.(statusCoder); ok {
+		status = sc.StatusCode()
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// credentials returns the value of the given header or query string
+// parameter. The bearer scheme prefix is removed from the Authorization
+// header value.
+func credentials(r *http.Request, in, key string) string {
+	if in == "query" {
+		return r.URL.Query().Get(key)
+	}
+	val := r.Header.Get(key)
+	if strings.EqualFold(key, "Authorization") {
+		if len(val) > 7 && strings.EqualFold(val[:7], "Bearer ") {
+			return val[7:]
+		}
+	}
+	return val
+}
@@ -0,0 +1,141 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ctxKey int
+
+func TestBasicAuth(t *testing.T) {
+	var user, pass string
+	fn := func(ctx context.Context, u, p string, s *BasicAuthScheme) (context.Context, error) {
+		user, pass = u, p
+		return context.WithValue(ctx, ctxKey(0), s.Name), nil
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("user", "pass")
+	ctx, err := BasicAuth(fn, &BasicAuthScheme{Name: "basic"})(context.Background(), r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user != "user" || pass != "pass" {
+		t.Errorf("got credentials %q:%q, expected user:pass", user, pass)
+	}
+	if ctx.Value(ctxKey(0)) != "basic" {
+		t.Errorf("authorizer context not returned")
+	}
+
+	_, err = BasicAuth(fn, &BasicAuthScheme{Name: "basic"})(context.Background(), httptest.NewRequest("GET", "/", nil))
+	if err == nil {
+		t.Errorf("missing credentials: expected an error")
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Header   string
+		Value    string
+		URL      string
+		In       string
+		Key      string
+		Expected string
+	}{
+		{"header", "X-Secret", "secret", "/", "header", "X-Secret", "secret"},
+		{"query", "", "", "/?key=secret", "query", "key", "secret"},
+		{"bearer", "Authorization", "Bearer token", "/", "header", "Authorization", "token"},
+		{"bearer-case", "Authorization", "bearer token", "/", "header", "Authorization", "token"},
+		{"missing", "", "", "/", "header", "X-Secret", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", c.URL, nil)
+			if c.Header != "" {
+				r.Header.Set(c.Header, c.Value)
+			}
+			if actual := credentials(r, c.In, c.Key); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestNilAuthorizer(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.SetBasicAuth("user", "pass")
+	r.Header.Set("X-Secret", "secret")
+	authorizers := map[string]Authorizer{
+		"basic":  BasicAuth(nil, &BasicAuthScheme{Name: "basic"}),
+		"apikey": APIKey(nil, &APIKeyScheme{Name: "apikey", In: "header", Key: "X-Secret"}),
+		"oauth2": OAuth2(nil, &OAuth2Scheme{Name: "oauth2"}),
+		"jwt":    JWT(nil, &JWTScheme{Name: "jwt", In: "header", Key: "Authorization"}),
+	}
+	for n, a := range authorizers {
+		if _, err := a(context.Background(), r); err == nil {
+			t.Errorf("%s: expected an error", n)
+		}
+	}
+}
+
+func TestAllAny(t *testing.T) {
+	ok := func(ctx context.Context, r *http.Request) (context.Context, error) { return ctx, nil }
+	ko := func(ctx context.Context, r *http.Request) (context.Context, error) { return nil, errors.New("ko") }
+	cases := []struct {
+		Name       string
+		Authorizer Authorizer
+		Authorized bool
+	}{
+		{"all-ok", All(ok, ok), true},
+		{"all-ko", All(ok, ko), false},
+		{"any-ok", Any(ko, ok), true},
+		{"any-ko", Any(ko, ko), false},
+		{"any-of-all", Any(All(ok, ko), All(ok, ok)), true},
+		{"any-empty", Any(), true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := c.Authorizer(context.Background(), httptest.NewRequest("GET", "/", nil))
+			if (err == nil) != c.Authorized {
+				t.Errorf("got error %v, expected authorized to be %t", err, c.Authorized)
+			}
+		})
+	}
+}
+
+func TestHasScopes(t *testing.T) {
+	if err := HasScopes("jwt", []string{"api:read", "api:write"}, []string{"api:write"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := HasScopes("jwt", []string{"api:read"}, []string{"api:read", "api:write"})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if err.Error() != "jwt: missing scopes api:write" {
+		t.Errorf("got error %q", err.Error())
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Error    error
+		Expected int
+	}{
+		{"unauthorized", Unauthorized("basic", "invalid credentials"), http.StatusUnauthorized},
+		{"forbidden", Forbidden("jwt", "missing scopes"), http.StatusForbidden},
+		{"other", errors.New("invalid"), http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, httptest.NewRequest("GET", "/", nil), c.Error)
+			if w.Code != c.Expected {
+				t.Errorf("got status %d, expected %d", w.Code, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package testdata
+
+var BasicAuthAuthorizersCode = `// Authorizers lists the functions used to authorize the requests made to the
+// secured BasicAuth service endpoints.
+type Authorizers struct {
+	// Basic authorizes the requests secured with the "basic" scheme.
+	Basic security.BasicAuthFunc
+	// ErrorHandler writes the response of the requests that fail
+	// authorization, security.WriteError is used if nil.
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+}
+
+// authorizersKey is the request context key used to store the authorizers.
+type authorizersKey struct{}
+
+// Authorize returns a HTTP handler that makes the given authorizers available
+// to the handlers of the secured BasicAuth service endpoints before calling h.
+// The requests made to the secured endpoints are rejected if h is not wrapped
+// with Authorize.
+func Authorize(h http.Handler, a *Authorizers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authorizersKey{}, a)))
+	})
+}
+
+// contextAuthorizers returns the authorizers stored in the given context.
+func contextAuthorizers(ctx context.Context) *Authorizers {
+	if a, ok := ctx.Value(authorizersKey{}).(*Authorizers); ok && a != nil {
+		return a
+	}
+	return &Authorizers{}
+}
+
+// basicAuthorizer calls the Basic function of the authorizers stored in the
+// request context.
+func basicAuthorizer(ctx context.Context, user, pass string, s *security.BasicAuthScheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Basic
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, user, pass, s)
+}
+`
+
+var MultiRequirementsAuthorizersCode = `// Authorizers lists the functions used to authorize the requests made to the
+// secured MultiRequirements service endpoints.
+type Authorizers struct {
+	// Basic authorizes the requests secured with the "basic" scheme.
+	Basic security.BasicAuthFunc
+	// Secret authorizes the requests secured with the "secret" scheme.
+	Secret security.APIKeyFunc
+	// Token authorizes the requests secured with the "token" scheme.
+	Token security.JWTFunc
+	// ErrorHandler writes the response of the requests that fail
+	// authorization, security.WriteError is used if nil.
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+}
+
+// authorizersKey is the request context key used to store the authorizers.
+type authorizersKey struct{}
+
+// Authorize returns a HTTP handler that makes the given authorizers available
+// to the handlers of the secured MultiRequirements service endpoints before
+// calling h. The requests made to the secured endpoints are rejected if h is
+// not wrapped with Authorize.
+func Authorize(h http.Handler, a *Authorizers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authorizersKey{}, a)))
+	})
+}
+
+// contextAuthorizers returns the authorizers stored in the given context.
+func contextAuthorizers(ctx context.Context) *Authorizers {
+	if a, ok := ctx.Value(authorizersKey{}).(*Authorizers); ok && a != nil {
+		return a
+	}
+	return &Authorizers{}
+}
+
+// basicAuthorizer calls the Basic function of the authorizers stored in the
+// request context.
+func basicAuthorizer(ctx context.Context, user, pass string, s *security.BasicAuthScheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Basic
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, user, pass, s)
+}
+
+// secretAuthorizer calls the Secret function of the authorizers stored in the
+// request context.
+func secretAuthorizer(ctx context.Context, cred string, s *security.APIKeyScheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Secret
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, cred, s)
+}
+
+// tokenAuthorizer calls the Token function of the authorizers stored in the
+// request context.
+func tokenAuthorizer(ctx context.Context, cred string, s *security.JWTScheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Token
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, cred, s)
+}
+`
+
+var APISecurityAuthorizersCode = `// Authorizers lists the functions used to authorize the requests made to the
+// secured APISecurity service endpoints.
+type Authorizers struct {
+	// Token authorizes the requests secured with the "token" scheme.
+	Token security.JWTFunc
+	// Google authorizes the requests secured with the "google" scheme.
+	Google security.OAuth2Func
+	// ErrorHandler writes the response of the requests that fail
+	// authorization, security.WriteError is used if nil.
+	ErrorHandler func(http.ResponseWriter, *http.Request, error)
+}
+
+// authorizersKey is the request context key used to store the authorizers.
+type authorizersKey struct{}
+
+// Authorize returns a HTTP handler that makes the given authorizers available
+// to the handlers of the secured APISecurity service endpoints before calling
+// h. The requests made to the secured endpoints are rejected if h is not
+// wrapped with Authorize.
+func Authorize(h http.Handler, a *Authorizers) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authorizersKey{}, a)))
+	})
+}
+
+// contextAuthorizers returns the authorizers stored in the given context.
+func contextAuthorizers(ctx context.Context) *Authorizers {
+	if a, ok := ctx.Value(authorizersKey{}).(*Authorizers); ok && a != nil {
+		return a
+	}
+	return &Authorizers{}
+}
+
+// tokenAuthorizer calls the Token function of the authorizers stored in the
+// request context.
+func tokenAuthorizer(ctx context.Context, cred string, s *security.JWTScheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Token
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, cred, s)
+}
+
+// googleAuthorizer calls the Google function of the authorizers stored in the
+// request context.
+func googleAuthorizer(ctx context.Context, cred string, s *security.OAuth2Scheme) (context.Context, error) {
+	fn := contextAuthorizers(ctx).Google
+	if fn == nil {
+		return nil, security.Unauthorized(s.Name, "no authorizer")
+	}
+	return fn(ctx, cred, s)
+}
+`
+
+var BasicAuthAuthorizeCode = `// authorizeSecure returns a HTTP handler that authorizes the requests made to
+// the Secure endpoint of the BasicAuth service before calling h.
+func authorizeSecure(h http.Handler) http.Handler {
+	authorize := security.Any(
+		security.All(
+			security.BasicAuth(basicAuthorizer, &security.BasicAuthScheme{Name: "basic"}),
+		),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authorize(r.Context(), r)
+		if err != nil {
+			eh := contextAuthorizers(r.Context()).ErrorHandler
+			if eh == nil {
+				eh = security.WriteError
+			}
+			eh(w, r, err)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+`
+
+var MultiRequirementsAuthorizeCode = `// authorizeSecure returns a HTTP handler that authorizes the requests made to
+// the Secure endpoint of the MultiRequirements service before calling h.
+func authorizeSecure(h http.Handler) http.Handler {
+	authorize := security.Any(
+		security.All(
+			security.APIKey(secretAuthorizer, &security.APIKeyScheme{Name: "secret", In: "header", Key: "X-Secret"}),
+		),
+		security.All(
+			security.BasicAuth(basicAuthorizer, &security.BasicAuthScheme{Name: "basic"}),
+			security.JWT(tokenAuthorizer, &security.JWTScheme{Name: "token", In: "header", Key: "Authorization", Scopes: []string{"api:read", "api:write"}, RequiredScopes: []string{"api:write"}}),
+		),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authorize(r.Context(), r)
+		if err != nil {
+			eh := contextAuthorizers(r.Context()).ErrorHandler
+			if eh == nil {
+				eh = security.WriteError
+			}
+			eh(w, r, err)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+`
+
+var APISecurityAuthorizeCode = `// authorizeSecure returns a HTTP handler that authorizes the requests made to
+// the Secure endpoint of the APISecurity service before calling h.
+func authorizeSecure(h http.Handler) http.Handler {
+	authorize := security.Any(
+		security.All(
+			security.JWT(tokenAuthorizer, &security.JWTScheme{Name: "token", In: "header", Key: "Authorization", Scopes: []string{"api:read", "api:write"}, RequiredScopes: []string{"api:read"}}),
+			security.OAuth2(googleAuthorizer, &security.OAuth2Scheme{Name: "google", Scopes: []string{"profile"}, RequiredScopes: []string{"profile"}}),
+		),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := authorize(r.Context(), r)
+		if err != nil {
+			eh := contextAuthorizers(r.Context()).ErrorHandler
+			if eh == nil {
+				eh = security.WriteError
+			}
+			eh(w, r, err)
+			return
+		}
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+`
@@ -0,0 +1,92 @@
+package testdata
+
+import (
+	. "goa.design/goa/design"
+	. "goa.design/plugins/security/dsl"
+)
+
+var Basic = BasicAuthSecurity("basic")
+
+var Secret = APIKeySecurity("secret", func() {
+	In("header", "X-Secret")
+})
+
+var Token = JWTSecurity("token", func() {
+	Scope("api:read", "Read access")
+	Scope("api:write", "Write access")
+})
+
+var Google = OAuth2Security("google", func() {
+	ImplicitFlow("https://accounts.google.com/o/oauth2/auth", "")
+	Scope("profile", "Profile access")
+})
+
+var BasicAuthDSL = func() {
+	Service("BasicAuth", func() {
+		Method("Secure", func() {
+			Security(Basic)
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var MultiRequirementsDSL = func() {
+	Service("MultiRequirements", func() {
+		Security(Secret)
+		Security(Basic, "token", func() {
+			Scope("api:write")
+		})
+		Method("Secure", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("Unsecure", func() {
+			NoSecurity()
+			HTTP(func() {
+				GET("/unsecure")
+			})
+		})
+	})
+}
+
+var APISecurityDSL = func() {
+	API("APISecurity", func() {
+		Security(Token, Google, func() {
+			Scope("api:read")
+			Scope("profile")
+		})
+	})
+	Service("APISecurity", func() {
+		Method("Secure", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var NoSecurityDSL = func() {
+	Service("NoSecurity", func() {
+		Method("Unsecure", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidScopeDSL = func() {
+	Service("InvalidScope", func() {
+		Method("Secure", func() {
+			Security(Token, func() {
+				Scope("api:admin")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package zaplogger
+
+import (
+	"fmt"
+	"regexp"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/eval"
+)
+
+const pluginName = "zaplogger"
+
+// Register the plugin Generator functions.
+func init() {
+	codegen.RegisterPlugin(pluginName, "example", Example)
+}
+
+var (
+	// loggerDeclRegexp matches the declaration of the goa log adapter in the
+	// example main function. The first submatch is the indentation.
+	loggerDeclRegexp = regexp.MustCompile(`(?m)^([ \t]*)adapter[ \t]+middleware\.Logger[ \t]*$`)
+
+	// stdLoggerRegexp matches the initialization of the standard logger in
+	// the example main function. The first submatch is the indentation and
+	// the second the logger prefix.
+	stdLoggerRegexp = regexp.MustCompile(`(?m)^([ \t]*)logger[ \t]*=[ \t]*log\.New\(os\.Stderr,[ \t]*"\[([^"\]]*)\] ",[^\n]*\)[ \t]*$`)
+
+	// adapterRegexp matches the initialization of the goa log adapter in the
+	// example main function.
+	adapterRegexp = regexp.MustCompile(`adapter[ \t]*=[ \t]*middleware\.NewLogger\(logger\)`)
+
+	// endpointsRegexp matches the initialization of the service endpoints
+	// in the example main function. The first submatch is the indentation
+	// and the second the name of the endpoints variable.
+	endpointsRegexp = regexp.MustCompile(`(?m)^([ \t]+)([^\t\n=][^\n=]*Endpoints)[ \t]*=[ \t]*[^\n]*NewEndpoints\([^\n]*\)[ \t]*$`)
+
+	// debugFlagRegexp matches the definition of the debug flag in the
+	// example main function. The first submatch is the flag variable name.
+	debugFlagRegexp = regexp.MustCompile(`(\w+)[ \t]*=[ \t]*flag\.Bool\("debug",`)
+)
+
+// Example modifies the generated main function so that the services use a
+// zap logger. The logger is used to log the HTTP requests and to create the
+// per-request loggers made available to the service methods via FromContext.
+// Example fails if the main function does not initialize the loggers and the
+// service endpoints as expected.
+func Example(genpkg string, roots []eval.Root, files []*codegen.File) ([]*codegen.File, error) {
+	for _, f := range files {
+		for _, s := range f.Section("service-main") {
+			src, err := zapify(s.Source)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", f.Path, err)
+			}
+			codegen.AddImport(f.SectionTemplates[0],
+				&codegen.ImportSpec{Path: "go.uber.org/zap"},
+				&codegen.ImportSpec{Path: "goa.design/plugins/zaplogger"})
+			s.Source = src
+		}
+	}
+	return files, nil
+}
+
+// zapify modifies the example main function template source so that it
+// creates a zap logger, uses it to back the standard logger and the goa log
+// adapter and applies the zaplogger middleware to the service endpoints. The
+// zap logger writes debug entries if the main function defines a "debug"
+// flag. zapify returns an error and leaves the source untouched if any of the
+// statements it modifies cannot be found.
+func zapify(src string) (string, error) {
+	for _, c := range []struct {
+		re   *regexp.Regexp
+		stmt string
+	}{
+		{loggerDeclRegexp, "logger declarations"},
+		{stdLoggerRegexp, "standard logger initialization"},
+		{adapterRegexp, "log adapter initialization"},
+		{endpointsRegexp, "service endpoints initialization"},
+	} {
+		if !c.re.MatchString(src) {
+			return "", fmt.Errorf("%s: unable to find the %s in the example main function", pluginName, c.stmt)
+		}
+	}
+	debug := "false"
+	if m := debugFlagRegexp.FindStringSubmatch(src); m != nil {
+		debug = "*" + m[1]
+	}
+	src = loggerDeclRegexp.ReplaceAllString(src, "${0}\n${1}zlogger *zap.Logger")
+	src = stdLoggerRegexp.ReplaceAllString(src, "${1}zlogger = zaplogger.New(\"${2}\", "+debug+")\n${1}logger = zap.NewStdLog(zlogger)")
+	src = adapterRegexp.ReplaceAllString(src, "adapter = zaplogger.NewLogger(zlogger)")
+	src = endpointsRegexp.ReplaceAllString(src, "${0}\n${1}${2}.Use(zaplogger.Middleware(zlogger))")
+	return src, nil
+}
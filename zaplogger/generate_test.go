@@ -0,0 +1,122 @@
+package zaplogger
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var calcDSL = func() {
+	Service("calc", func() {
+		Method("add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+				Attribute("b", Int)
+			})
+			Result(Int)
+			HTTP(func() {
+				GET("/add/{a}/{b}")
+			})
+		})
+	})
+}
+
+func TestExample(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, calcDSL)
+	fs := httpcodegen.ExampleServerFiles("", httpdesign.Root)
+	sources := make(map[*codegen.SectionTemplate]string)
+	for _, f := range fs {
+		for _, s := range f.SectionTemplates {
+			sources[s] = s.Source
+		}
+	}
+	if _, err := Example("", nil, fs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var found bool
+	for _, f := range fs {
+		for _, s := range f.SectionTemplates[1:] {
+			if s.Name != "service-main" {
+				if s.Source != sources[s] {
+					t.Errorf("%s: expected template to be left untouched", s.Name)
+				}
+				continue
+			}
+			found = true
+			for _, code := range []string{
+				"zlogger *zap.Logger",
+				`zlogger = zaplogger.New("{{ .APIPkg }}", *dbg)`,
+				"logger = zap.NewStdLog(zlogger)",
+				"adapter = zaplogger.NewLogger(zlogger)",
+				"Endpoints.Use(zaplogger.Middleware(zlogger))",
+			} {
+				if !strings.Contains(s.Source, code) {
+					t.Errorf("service-main: invalid template, expected to contain %q", code)
+				}
+			}
+			for _, code := range []string{"log.New(os.Stderr", "middleware.NewLogger("} {
+				if strings.Contains(s.Source, code) {
+					t.Errorf("service-main: invalid template, expected not to contain %q", code)
+				}
+			}
+			var buf strings.Builder
+			if err := f.SectionTemplates[0].Write(&buf); err != nil {
+				t.Fatalf("header: %v", err)
+			}
+			for _, path := range []string{"go.uber.org/zap", "goa.design/plugins/zaplogger"} {
+				if !strings.Contains(buf.String(), path) {
+					t.Errorf("header: expected import of %q", path)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Fatal("no service-main section found")
+	}
+}
+
+func TestZapify(t *testing.T) {
+	const (
+		mainT = `	var (
+		adapter middleware.Logger
+		logger  *log.Logger
+	)
+	{
+		logger = log.New(os.Stderr, "[{{ .APIPkg }}] ", log.Ltime|log.Lshortfile)
+		adapter = middleware.NewLogger(logger)
+	}
+	{
+		calcEndpoints = calcsvc.NewEndpoints(calcSvc)
+	}
+`
+		zapMainT = `	var (
+		adapter middleware.Logger
+		zlogger *zap.Logger
+		logger  *log.Logger
+	)
+	{
+		zlogger = zaplogger.New("{{ .APIPkg }}", false)
+		logger = zap.NewStdLog(zlogger)
+		adapter = zaplogger.NewLogger(zlogger)
+	}
+	{
+		calcEndpoints = calcsvc.NewEndpoints(calcSvc)
+		calcEndpoints.Use(zaplogger.Middleware(zlogger))
+	}
+`
+	)
+	src, err := zapify(mainT)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if src != zapMainT {
+		t.Errorf("invalid template, got:\n%s\nexpected:\n%s", src, zapMainT)
+	}
+	if _, err := zapify(strings.Replace(mainT, "middleware.NewLogger(logger)", "newLogger(logger)", 1)); err == nil {
+		t.Error("expected an error when the log adapter initialization is missing")
+	}
+}
@@ -0,0 +1,116 @@
+package zaplogger
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	goa "goa.design/goa"
+	"goa.design/goa/http/middleware"
+)
+
+type (
+	// adapter is a middleware.Logger implementation backed by a zap logger.
+	adapter struct {
+		logger *zap.Logger
+	}
+
+	// ctxKey is the type of the context key used to store the request
+	// loggers.
+	ctxKey int
+)
+
+// loggerKey is the context key used to store the request loggers.
+const loggerKey ctxKey = iota + 1
+
+// New returns a logger that writes JSON entries to stderr. The entries carry
+// the given name in the "logger" field. The logger writes entries at the
+// info level and above unless debug is true in which case it also writes
+// debug entries. New falls back to a logger writing human readable entries to
+// stderr if the JSON logger cannot be built and logs the failure.
+func New(name string, debug bool) *zap.Logger {
+	conf := zap.NewProductionConfig()
+	if debug {
+		conf.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	}
+	logger, err := conf.Build()
+	if err != nil {
+		enc := zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+		logger = zap.New(zapcore.NewCore(enc, zapcore.Lock(os.Stderr), conf.Level))
+		logger.Error("failed to build JSON logger", zap.Error(err))
+	}
+	return logger.Named(name)
+}
+
+// NewLogger returns a goa middleware.Logger that writes entries with the
+// given zap logger. The key/value pairs are written as fields of info level
+// entries, the value of the "msg" key if any is used as entry message.
+func NewLogger(logger *zap.Logger) middleware.Logger {
+	return &adapter{logger: logger}
+}
+
+// Log writes an entry with the given key/value pairs.
+func (a *adapter) Log(keyvals ...interface{}) error {
+	var (
+		msg    string
+		fields = make([]zap.Field, 0, (len(keyvals)+1)/2)
+	)
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var val interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			val = keyvals[i+1]
+		}
+		if key == "msg" {
+			msg = fmt.Sprint(val)
+			continue
+		}
+		fields = append(fields, zap.Any(key, val))
+	}
+	a.logger.Info(msg, fields...)
+	return nil
+}
+
+// Middleware returns an endpoint middleware that stores a request logger in
+// the context given to the endpoint. The request logger is derived from the
+// given logger and carries the request ID, service and method fields. Use
+// FromContext to retrieve the request logger in the service methods.
+func Middleware(logger *zap.Logger) func(goa.Endpoint) goa.Endpoint {
+	return func(e goa.Endpoint) goa.Endpoint {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			return e(WithLogger(ctx, RequestLogger(ctx, logger)), req)
+		}
+	}
+}
+
+// RequestLogger returns a logger derived from the given logger that carries
+// the request ID, service and method fields read from the context.
+func RequestLogger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	var fields []zap.Field
+	if id, ok := ctx.Value(middleware.RequestIDKey).(string); ok {
+		fields = append(fields, zap.String("id", id))
+	}
+	if svc, ok := ctx.Value(goa.ServiceKey).(string); ok {
+		fields = append(fields, zap.String("service", svc))
+	}
+	if m, ok := ctx.Value(goa.MethodKey).(string); ok {
+		fields = append(fields, zap.String("method", m))
+	}
+	return logger.With(fields...)
+}
+
+// WithLogger returns a copy of the given context that stores the logger.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext returns the logger stored in the context by Middleware or
+// WithLogger. It returns a logger that writes nothing if there isn't one.
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.NewNop()
+}
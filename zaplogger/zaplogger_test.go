@@ -0,0 +1,70 @@
+package zaplogger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	goa "goa.design/goa"
+	"goa.design/goa/http/middleware"
+)
+
+func TestLoggerAdapter(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	adapter := NewLogger(zap.New(core))
+	if err := adapter.Log("id", "abc", "msg", "request", "status", 200, "dangling"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, expected 1", len(entries))
+	}
+	if entries[0].Message != "request" {
+		t.Errorf("got message %q, expected %q", entries[0].Message, "request")
+	}
+	fields := entries[0].ContextMap()
+	expected := map[string]interface{}{"id": "abc", "status": int64(200), "dangling": "MISSING"}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("field %q: got %#v, expected %#v", k, fields[k], v)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	var logged bool
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		FromContext(ctx).Info("handled")
+		logged = true
+		return nil, nil
+	}
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "abc")
+	ctx = context.WithValue(ctx, goa.ServiceKey, "calc")
+	ctx = context.WithValue(ctx, goa.MethodKey, "add")
+	if _, err := Middleware(zap.New(core))(e)(ctx, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !logged {
+		t.Fatal("endpoint not called")
+	}
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, expected 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	expected := map[string]interface{}{"id": "abc", "service": "calc", "method": "add"}
+	for k, v := range expected {
+		if fields[k] != v {
+			t.Errorf("field %q: got %#v, expected %#v", k, fields[k], v)
+		}
+	}
+}
+
+func TestFromContextDefault(t *testing.T) {
+	if FromContext(context.Background()) == nil {
+		t.Error("expected a logger")
+	}
+}